@@ -41,14 +41,21 @@ func UpdateAlmanaxBonusIndex(init bool, db *database.Repository) int {
 
 	added := 0
 
-	for _, lang := range config.Languages {
-		bonusTypes, err := db.GetBonusTypes()
-		if err != nil {
-			log.Error(err, "lang", lang)
-			return added
-		}
+	bonusTypes, err := db.GetBonusTypes()
+	if err != nil {
+		log.Error(err)
+		return added
+	}
+
+	// every language of the stored texts gets an index, also ones that were added to the game data later
+	languages, err := db.GetAlmanaxLanguages()
+	if err != nil {
+		log.Error(err)
+		return added
+	}
 
-		bonuses := bonusListingsToBonusIdTranslated(bonusTypes, lang)
+	for _, lang := range languages {
+		bonuses := BonusListingsToBonusIdTranslated(bonusTypes, lang)
 
 		var bonusesMeili []AlmanaxBonusListingMeili
 		var counter int = 0
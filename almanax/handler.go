@@ -2,7 +2,6 @@ package almanax
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
 	"net/http"
@@ -21,8 +20,32 @@ import (
 
 var bonusDescriptionTemplateRe = regexp.MustCompile(`{{([^,]+),([0-9]+)::([^{]+)}}`)
 
+// renderLocalized is utils.RenderLocalized for renders that can fail.
+func renderLocalized(r *http.Request, render func(lang string) (any, error)) (any, error) {
+	langs, localized := utils.RequestLanguages(r)
+	renders := make([]any, len(langs))
+	for i, lang := range langs {
+		var err error
+		if renders[i], err = render(lang); err != nil {
+			return nil, err
+		}
+	}
+
+	if !localized {
+		var fallbackErr error
+		response := utils.Fallback(r, renders[0], func(lang string) any {
+			rendered, err := render(lang)
+			if err != nil {
+				fallbackErr = err
+			}
+			return rendered
+		})
+		return response, fallbackErr
+	}
+	return utils.Localize(renders, langs), nil
+}
+
 func GetAlmanaxSingle(w http.ResponseWriter, r *http.Request) {
-	lang := r.Context().Value("lang").(string)
 	date := r.Context().Value("date").(time.Time)
 	level := r.URL.Query().Get("level")
 
@@ -65,7 +88,9 @@ func GetAlmanaxSingle(w http.ResponseWriter, r *http.Request) {
 	itemDb := database.Db.Txn(false)
 	defer itemDb.Abort()
 
-	response, err := renderAlmanaxResponse(&mappedAlmanax[0], lang, levelInt, itemDb)
+	response, err := renderLocalized(r, func(lang string) (any, error) {
+		return RenderAlmanaxResponse(&mappedAlmanax[0], lang, levelInt, itemDb)
+	})
 	if err != nil {
 		e.WriteServerErrorResponse(w, "Could not render Almanax response. "+err.Error())
 		return
@@ -75,9 +100,9 @@ func GetAlmanaxSingle(w http.ResponseWriter, r *http.Request) {
 	utils.RequestsAlmanaxSingle.Inc()
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(response)
+	err = utils.WriteData(w, r, response)
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -99,7 +124,7 @@ func experienceReward(playerLevel, optimalLevel int, xpRatio, duration float64)
 	return int(math.Floor(float64(playerLevel) * math.Pow(100.0+2.0*float64(playerLevel), 2.0) / 20.0 * duration * xpRatio))
 }
 
-func renderAlmanaxResponse(m *database.MappedAlmanax, lang string, level *int, txn *memdb.Txn) (AlmanaxResponse, error) {
+func RenderAlmanaxResponse(m *database.MappedAlmanax, lang string, level *int, txn *memdb.Txn) (AlmanaxResponse, error) {
 	var response AlmanaxResponse
 	response.Date = m.Almanax.Date
 	response.Bonus.BonusType.Id = m.BonusType.NameID
@@ -122,28 +147,9 @@ func renderAlmanaxResponse(m *database.MappedAlmanax, lang string, level *int, t
 	item := raw.(*mapping.MappedMultilangItemUnity)
 	response.Tribute.Item.ImageUrls = RenderImageUrls(utils.ImageUrls(item.IconId, "item", config.ItemImgResolutions, config.ApiScheme, config.MajorVersion, config.ApiHostName, config.IsBeta))
 
-	switch lang {
-	case "en":
-		response.Bonus.Description = m.Bonus.DescriptionEn
-		response.Bonus.BonusType.Name = m.BonusType.NameEn
-		response.Tribute.Item.Name = m.Tribute.ItemNameEn
-	case "fr":
-		response.Bonus.Description = m.Bonus.DescriptionFr
-		response.Bonus.BonusType.Name = m.BonusType.NameFr
-		response.Tribute.Item.Name = m.Tribute.ItemNameFr
-	case "de":
-		response.Bonus.Description = m.Bonus.DescriptionDe
-		response.Bonus.BonusType.Name = m.BonusType.NameDe
-		response.Tribute.Item.Name = m.Tribute.ItemNameDe
-	case "es":
-		response.Bonus.Description = m.Bonus.DescriptionEs
-		response.Bonus.BonusType.Name = m.BonusType.NameEs
-		response.Tribute.Item.Name = m.Tribute.ItemNameEs
-	case "pt":
-		response.Bonus.Description = m.Bonus.DescriptionPt
-		response.Bonus.BonusType.Name = m.BonusType.NamePt
-		response.Tribute.Item.Name = m.Tribute.ItemNamePt
-	}
+	response.Bonus.Description = m.Bonus.Descriptions[lang]
+	response.Bonus.BonusType.Name = m.BonusType.Names[lang]
+	response.Tribute.Item.Name = m.Tribute.ItemNames[lang]
 
 	// replace templated links inside the bonus description; TODO replace this later with a meta link to the linked item or monster
 	response.Bonus.Description = bonusDescriptionTemplateRe.ReplaceAllStringFunc(response.Bonus.Description, func(match string) string {
@@ -163,7 +169,6 @@ func renderAlmanaxResponse(m *database.MappedAlmanax, lang string, level *int, t
 }
 
 func GetAlmanaxRange(w http.ResponseWriter, r *http.Request) {
-	lang := r.Context().Value("lang").(string)
 	from := r.URL.Query().Get("range[from]")
 	to := r.URL.Query().Get("range[to]")
 	size := r.URL.Query().Get("range[size]")
@@ -313,7 +318,6 @@ func GetAlmanaxRange(w http.ResponseWriter, r *http.Request) {
 	fromDateStr := fromDate.Format("2006-01-02")
 	toDateStr := toDate.Format("2006-01-02")
 
-	res := make([]AlmanaxResponse, 0)
 	var mappedAlmanax []database.MappedAlmanax
 	if bonusType != "" {
 		mappedAlmanax, err = almDb.GetAlmanaxByDateRangeAndNameID(fromDateStr, toDateStr, bonusType)
@@ -334,43 +338,39 @@ func GetAlmanaxRange(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	for _, m := range mappedAlmanax {
-		response, err := renderAlmanaxResponse(&m, lang, levelInt, itemDb)
-		if err != nil {
-			e.WriteServerErrorResponse(w, "Could not render Almanax response. "+err.Error())
-			return
+	res, err := renderLocalized(r, func(lang string) (any, error) {
+		res := make([]AlmanaxResponse, 0, len(mappedAlmanax))
+		for _, m := range mappedAlmanax {
+			response, err := RenderAlmanaxResponse(&m, lang, levelInt, itemDb)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, response)
 		}
-		res = append(res, response)
+		return res, nil
+	})
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not render Almanax response. "+err.Error())
+		return
 	}
 
 	utils.RequestsTotal.Inc()
 	utils.RequestsAlmanaxRange.Inc()
 
 	utils.WriteCacheHeader(&w)
-	encodeErr := json.NewEncoder(w).Encode(res)
+	encodeErr := utils.WriteData(w, r, res)
 	if encodeErr != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
 
-func bonusListingsToBonusIdTranslated(bonuses []database.BonusType, lang string) []AlmanaxBonusListing {
+func BonusListingsToBonusIdTranslated(bonuses []database.BonusType, lang string) []AlmanaxBonusListing {
 	bonusesTranslated := make([]AlmanaxBonusListing, 0, len(bonuses))
 	for _, bonus := range bonuses {
 		var bonusTranslated AlmanaxBonusListing
 		bonusTranslated.Id = bonus.NameID
-		switch lang {
-		case "en":
-			bonusTranslated.Name = bonus.NameEn
-		case "fr":
-			bonusTranslated.Name = bonus.NameFr
-		case "de":
-			bonusTranslated.Name = bonus.NameDe
-		case "es":
-			bonusTranslated.Name = bonus.NameEs
-		case "pt":
-			bonusTranslated.Name = bonus.NamePt
-		}
+		bonusTranslated.Name = bonus.Names[lang]
 		bonusesTranslated = append(bonusesTranslated, bonusTranslated)
 	}
 
@@ -378,7 +378,6 @@ func bonusListingsToBonusIdTranslated(bonuses []database.BonusType, lang string)
 }
 
 func ListBonuses(w http.ResponseWriter, r *http.Request) {
-	lang := r.Context().Value("lang").(string)
 	db := database.NewDatabaseRepository(context.Background(), config.DbDir)
 
 	bonuses, err := db.GetBonusTypes()
@@ -392,12 +391,12 @@ func ListBonuses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bonusesTranslated := bonusListingsToBonusIdTranslated(bonuses, lang)
-
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(bonusesTranslated)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		return BonusListingsToBonusIdTranslated(bonuses, lang)
+	}))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -467,6 +466,7 @@ func SearchBonuses(w http.ResponseWriter, r *http.Request) {
 		Slug string `json:"slug"`
 	}
 
+	var slugs []string
 	for _, hitRaw := range searchResp.Hits {
 		hit := Hit{}
 		err = hitRaw.DecodeInto(&hit)
@@ -479,12 +479,44 @@ func SearchBonuses(w http.ResponseWriter, r *http.Request) {
 			Name: hit.Name,
 		}
 		results = append(results, almBonus)
+		slugs = append(slugs, hit.Slug)
+	}
+
+	if _, localized := utils.RequestLanguages(r); !localized {
+		utils.WriteCacheHeader(&w)
+		err = utils.WriteData(w, r, results)
+		if err != nil {
+			e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		}
+		return
+	}
+
+	// the index only has the names in the searched language, the others come from the database
+	db := database.NewDatabaseRepository(context.Background(), config.DbDir)
+	defer db.Deinit()
+
+	bonuses, err := db.GetBonusTypes()
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not get bonus types: "+err.Error())
+		return
+	}
+
+	hitBonuses := make([]database.BonusType, 0, len(slugs))
+	for _, slug := range slugs {
+		for _, bonus := range bonuses {
+			if bonus.NameID == slug {
+				hitBonuses = append(hitBonuses, bonus)
+				break
+			}
+		}
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(results)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		return BonusListingsToBonusIdTranslated(hitBonuses, lang)
+	}))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
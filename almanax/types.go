@@ -9,9 +9,9 @@ const (
 type AlmanaxResponse struct {
 	Date  string `json:"date"`
 	Bonus struct {
-		Description string `json:"description"`
+		Description string `json:"description" localized:"true"`
 		BonusType   struct {
-			Name string `json:"name"`
+			Name string `json:"name" localized:"true"`
 			Id   string `json:"id"`
 		} `json:"type"`
 	} `json:"bonus"`
@@ -21,7 +21,7 @@ type AlmanaxResponse struct {
 		Item struct {
 			AnkamaId  int64        `json:"ankama_id"`
 			ImageUrls ApiImageUrls `json:"image_urls"`
-			Name      string       `json:"name"`
+			Name      string       `json:"name" localized:"true"`
 			Subtype   string       `json:"subtype"`
 		} `json:"item"`
 		Quantity int `json:"quantity"`
@@ -29,8 +29,8 @@ type AlmanaxResponse struct {
 }
 
 type AlmanaxBonusListing struct {
-	Id   string `json:"id"`   // english-id
-	Name string `json:"name"` // translated text
+	Id   string `json:"id"`                    // english-id
+	Name string `json:"name" localized:"true"` // translated text
 }
 
 type AlmanaxBonusListingMeili struct {
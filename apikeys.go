@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	apiKeyHeader     = "X-API-Key"
+	apiKeyQueryParam = "api_key"
+	apiKeyPrefix     = "dodu_"
+
+	// apiKeyCacheTtl is how long a looked up key is trusted, so revocations apply after at most this long.
+	apiKeyCacheTtl = time.Minute
+
+	apiKeyUsageFlushInterval = time.Minute
+	apiKeyUsageDefaultDays   = 30
+)
+
+// apiKeys is nil until the server starts. Without it, keys are ignored and nothing is counted.
+var apiKeys *apiKeyStore
+
+// generateApiKey returns a new random key and the prefix that identifies it in listings.
+func generateApiKey() (string, string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+	return key, key[:len(apiKeyPrefix)+8], nil
+}
+
+// hashApiKey is the stored form of a key. Keys are random, so a plain hash cannot be reversed by guessing.
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type apiKeyUsageKey struct {
+	apiKeyId int64
+	day      string
+	route    string
+}
+
+type cachedApiKey struct {
+	apiKey *database.ApiKey
+	loaded time.Time
+}
+
+// apiKeyStore looks up keys and counts requests in memory. The counts are added to the database periodically,
+// so requests do not wait for SQLite.
+type apiKeyStore struct {
+	repo  *database.Repository
+	mu    sync.Mutex
+	keys  map[string]cachedApiKey
+	usage map[apiKeyUsageKey]int64
+}
+
+func newApiKeyStore(repo *database.Repository) *apiKeyStore {
+	return &apiKeyStore{
+		repo:  repo,
+		keys:  make(map[string]cachedApiKey),
+		usage: make(map[apiKeyUsageKey]int64),
+	}
+}
+
+// lookup returns the stored key, nil if it is unknown. Revoked keys are returned with RevokedAt set.
+func (s *apiKeyStore) lookup(key string) (*database.ApiKey, error) {
+	keyHash := hashApiKey(key)
+
+	s.mu.Lock()
+	cached, ok := s.keys[keyHash]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loaded) < apiKeyCacheTtl {
+		return cached.apiKey, nil
+	}
+
+	apiKey, err := s.repo.GetApiKeyByHash(keyHash)
+	if err != nil || apiKey == nil { // unknown keys are not cached, random keys would fill the map
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.keys[keyHash] = cachedApiKey{apiKey: apiKey, loaded: time.Now()}
+	s.mu.Unlock()
+	return apiKey, nil
+}
+
+func (s *apiKeyStore) count(apiKeyId int64, route string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage[apiKeyUsageKey{apiKeyId: apiKeyId, day: now.UTC().Format("2006-01-02"), route: route}]++
+}
+
+// flush adds the counted requests to the database. Counts that could not be written are kept for the next flush.
+func (s *apiKeyStore) flush() error {
+	s.mu.Lock()
+	counted := s.usage
+	s.usage = make(map[apiKeyUsageKey]int64)
+	s.mu.Unlock()
+
+	if len(counted) == 0 {
+		return nil
+	}
+
+	usage := make([]database.ApiKeyUsage, 0, len(counted))
+	for key, requests := range counted {
+		usage = append(usage, database.ApiKeyUsage{ApiKeyID: key.apiKeyId, Day: key.day, Route: key.route, Requests: requests})
+	}
+
+	err := s.repo.AddApiKeyUsage(usage)
+	if err != nil {
+		s.mu.Lock()
+		for key, requests := range counted {
+			s.usage[key] += requests
+		}
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *apiKeyStore) flushEvery(interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := s.flush(); err != nil {
+			log.Warn("Could not store API usage, did you run doduapi migrate up?", "err", err)
+		}
+	}
+}
+
+// authenticate checks the API key from the X-API-Key header or the api_key query parameter and counts the request
+// for the key or as anonymous. The query parameter is removed, so it does not end up in links or cache keys.
+func authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiKeys == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := r.Header.Get(apiKeyHeader)
+		if query := r.URL.Query(); query.Has(apiKeyQueryParam) {
+			if key == "" {
+				key = query.Get(apiKeyQueryParam)
+			}
+			query.Del(apiKeyQueryParam)
+			r.URL.RawQuery = query.Encode()
+		}
+
+		apiKeyId := int64(database.AnonymousApiKeyId)
+		if key != "" {
+			apiKey, err := apiKeys.lookup(key)
+			if err != nil {
+				e.WriteServerErrorResponse(w, "Could not check the API key: "+err.Error())
+				return
+			}
+			if apiKey == nil || apiKey.RevokedAt != nil {
+				e.WriteInvalidApiKeyResponse(w, "Unknown or revoked API key.")
+				return
+			}
+			apiKeyId = apiKey.ID
+			r = r.WithContext(context.WithValue(r.Context(), "apiKey", apiKey))
+		}
+
+		next.ServeHTTP(w, r)
+
+		if route := chi.RouteContext(r.Context()).RoutePattern(); route != "" {
+			apiKeys.count(apiKeyId, r.Method+" "+strings.TrimPrefix(route, apiBasePath()), time.Now())
+		}
+	})
+}
+
+func GetApiKeyUsageHandler(w http.ResponseWriter, r *http.Request) {
+	apiKey, ok := r.Context().Value("apiKey").(*database.ApiKey)
+	if !ok {
+		e.WriteInvalidApiKeyResponse(w, "This endpoint needs an API key in the "+apiKeyHeader+" header.")
+		return
+	}
+
+	days := apiKeyUsageDefaultDays
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days < 1 || days > 365 {
+			e.WriteInvalidQueryResponse(w, "Invalid days, use a number from 1 to 365: "+daysStr)
+			return
+		}
+	}
+
+	if err := apiKeys.flush(); err != nil {
+		e.WriteServerErrorResponse(w, "Could not store the usage: "+err.Error())
+		return
+	}
+
+	fromDay := time.Now().UTC().AddDate(0, 0, 1-days).Format("2006-01-02")
+	usage, err := apiKeys.repo.GetApiKeyUsage(apiKey.ID, fromDay)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not load the usage: "+err.Error())
+		return
+	}
+
+	response := APIKeyUsage{
+		Key: APIKeyInfo{
+			Prefix:          apiKey.Prefix,
+			Owner:           apiKey.Owner,
+			CreatedAt:       apiKey.CreatedAt,
+			RateLimitFactor: apiKey.RateLimitFactor,
+		},
+		Usage: make([]APIKeyUsageEntry, len(usage)),
+	}
+	for i, entry := range usage {
+		response.Usage[i] = APIKeyUsageEntry{Day: entry.Day, Route: entry.Route, Requests: entry.Requests}
+	}
+
+	utils.WriteCacheHeader(&w)
+	w.Header().Set("Cache-Control", "private, no-store")
+	if err := utils.WriteData(w, r, response); err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
+
+func formatApiKey(apiKey database.ApiKey) string {
+	status := "active"
+	if apiKey.RevokedAt != nil {
+		status = "revoked " + apiKey.RevokedAt.Format(time.DateOnly)
+	}
+	return fmt.Sprintf("%d\t%s...\t%s\t%s\tx%g\t%s\t%s", apiKey.ID, apiKey.Prefix, apiKey.Owner, apiKey.Contact,
+		apiKey.RateLimitFactor, apiKey.CreatedAt.Format(time.DateOnly), status)
+}
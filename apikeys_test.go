@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/dofusdude/doduapi/database"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestApiKeys(t *testing.T) {
+	repo := database.NewDatabaseRepository(context.Background(), t.TempDir())
+	defer repo.Deinit()
+
+	applyMigrations(t, repo, "003_api_keys")
+
+	key, prefix, err := generateApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := repo.CreateApiKey(&database.ApiKey{KeyHash: hashApiKey(key), Prefix: prefix, Owner: "partner", RateLimitFactor: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiKeys = newApiKeyStore(repo)
+	defer func() { apiKeys = nil }()
+
+	router := chi.NewRouter()
+	router.Use(authenticate)
+	router.Get("/{lang}/items/equipment/{ankamaId}", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has(apiKeyQueryParam) {
+			t.Error("Expected the api_key parameter to be removed")
+		}
+		w.Write([]byte("{}"))
+	})
+
+	request := func(url string, header string) int {
+		r := httptest.NewRequest("GET", url, nil)
+		if header != "" {
+			r.Header.Set(apiKeyHeader, header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := request("/en/items/equipment/1", key); code != http.StatusOK {
+		t.Error("Expected a valid key to pass, got ", code)
+	}
+	if code := request("/en/items/equipment/1?api_key="+key, ""); code != http.StatusOK {
+		t.Error("Expected a valid key in the query to pass, got ", code)
+	}
+	if code := request("/en/items/equipment/1", "dodu_unknown"); code != http.StatusUnauthorized {
+		t.Error("Expected 401 for an unknown key, got ", code)
+	}
+	if code := request("/en/items/equipment/1", ""); code != http.StatusOK {
+		t.Error("Expected anonymous access, got ", code)
+	}
+
+	if err := apiKeys.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := repo.GetApiKeyUsage(id, "2000-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 1 || usage[0].Requests != 2 || usage[0].Route != "GET /{lang}/items/equipment/{ankamaId}" {
+		t.Error("Expected 2 requests to the equipment route, got ", usage)
+	}
+
+	anonymous, err := repo.GetApiKeyUsage(database.AnonymousApiKeyId, "2000-01-01")
+	if err != nil || len(anonymous) != 1 || anonymous[0].Requests != 1 {
+		t.Error("Expected 1 anonymous request, got ", anonymous, err)
+	}
+
+	if revoked, err := repo.RevokeApiKey(prefix); err != nil || !revoked {
+		t.Fatal("Expected the key to be revoked, got ", err)
+	}
+	apiKeys.keys = map[string]cachedApiKey{} // skip the cache time
+	if code := request("/en/items/equipment/1", key); code != http.StatusUnauthorized {
+		t.Error("Expected 401 for a revoked key, got ", code)
+	}
+}
+
+func TestRevokeApiKeyAmbiguousPrefix(t *testing.T) {
+	repo := database.NewDatabaseRepository(context.Background(), t.TempDir())
+	defer repo.Deinit()
+
+	applyMigrations(t, repo, "003_api_keys")
+
+	var ids []int64
+	for _, keyHash := range []string{"hash-a", "hash-b"} {
+		id, err := repo.CreateApiKey(&database.ApiKey{KeyHash: keyHash, Prefix: "0123abcd", Owner: "partner", RateLimitFactor: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if revoked, err := repo.RevokeApiKey("0123abcd"); err == nil || revoked {
+		t.Error("Expected an error and no revocation for a prefix of two keys")
+	}
+
+	if revoked, err := repo.RevokeApiKey(strconv.FormatInt(ids[0], 10)); err != nil || !revoked {
+		t.Fatal("Expected the first key to be revoked by id, got ", err)
+	}
+
+	// the prefix is unique again among the active keys
+	if revoked, err := repo.RevokeApiKey("0123abcd"); err != nil || !revoked {
+		t.Error("Expected the second key to be revoked by prefix, got ", err)
+	}
+	if revoked, err := repo.RevokeApiKey("0123abcd"); err != nil || revoked {
+		t.Error("Expected no active key left, got ", err)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+	"github.com/zyedidia/generic/set"
+)
+
+const maxBatchSize = 100
+
+var batchItemTypes = []string{"consumables", "resources", "equipment", "quest_items", "cosmetics"}
+
+type BatchReference struct {
+	Type string `json:"type"`
+	Id   int    `json:"id"`
+}
+
+type BatchRequest struct {
+	Entries []BatchReference `json:"entries"`
+}
+
+type APIBatchEntry struct {
+	Type  string `json:"type"`
+	Id    int    `json:"id"`
+	Found bool   `json:"found"`
+	Data  any    `json:"data,omitempty"`
+}
+
+type APIBatch struct {
+	Entries []APIBatchEntry `json:"entries"`
+}
+
+func (b APIBatch) ListEntries() any {
+	return b.Entries
+}
+
+func validBatchType(entityType string) bool {
+	if entityType == "items" || entityType == "sets" || entityType == "mounts" {
+		return true
+	}
+	for _, itemType := range batchItemTypes {
+		if itemType == entityType {
+			return true
+		}
+	}
+	return false
+}
+
+type batchExpansions struct {
+	item  *set.Set[string]
+	set   *set.Set[string]
+	mount *set.Set[string]
+}
+
+func parseBatchExpansions(r *http.Request) (batchExpansions, error) {
+	expansions := batchExpansions{
+		item:  parseFields(strings.ToLower(r.URL.Query().Get("fields[item]"))),
+		set:   parseFields(strings.ToLower(r.URL.Query().Get("fields[set]"))),
+		mount: parseFields(strings.ToLower(r.URL.Query().Get("fields[mount]"))),
+	}
+
+	if !validateFields(expansions.item, equipmentAllowedExpandFields) {
+		return expansions, fmt.Errorf("fields[item] has invalid fields")
+	}
+	if !validateFields(expansions.set, setAllowedExpandFields) {
+		return expansions, fmt.Errorf("fields[set] has invalid fields")
+	}
+	if !validateFields(expansions.mount, mountAllowedExpandFields) {
+		return expansions, fmt.Errorf("fields[mount] has invalid fields")
+	}
+
+	return expansions, nil
+}
+
+// resolveBatchEntry renders one referenced entity like its list endpoint does. A nil result means not found.
+func resolveBatchEntry(ref BatchReference, lang string, expansions batchExpansions, txn *memdb.Txn) (any, error) {
+	version := utils.CurrentRedBlueVersionStr(database.Version.MemDb)
+
+	var table string
+	switch ref.Type {
+	case "items":
+		table = "all_items"
+	case "sets":
+		table = "sets"
+	case "mounts":
+		table = "equipment"
+	default:
+		table = ref.Type
+	}
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", version, table), "id", ref.Id)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+
+	switch ref.Type {
+	case "sets":
+		return RenderSetListEntryExpanded(raw.(*mapping.MappedMultilangSetUnity), lang, expansions.set), nil
+	case "mounts":
+		item := raw.(*mapping.MappedMultilangItemUnity)
+		if !mountEquipmentTypeIds[item.Type.ItemTypeId] {
+			return nil, nil
+		}
+		return RenderMountListEntryExpanded(item, lang, expansions.mount), nil
+	default:
+		return RenderItemListEntryExpanded(raw.(*mapping.MappedMultilangItemUnity), lang, expansions.item, txn), nil
+	}
+}
+
+func BatchHandler(w http.ResponseWriter, r *http.Request) {
+	langs, localized := utils.RequestLanguages(r)
+
+	expansions, err := parseBatchExpansions(r)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, err.Error())
+		return
+	}
+
+	var request BatchRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		e.WriteInvalidJsonResponse(w, "Could not decode batch request: "+err.Error())
+		return
+	}
+
+	if len(request.Entries) == 0 {
+		e.WriteInvalidJsonResponse(w, "entries must not be empty.")
+		return
+	}
+
+	if len(request.Entries) > maxBatchSize {
+		e.WriteInvalidJsonResponse(w, fmt.Sprintf("entries accepts at most %d references.", maxBatchSize))
+		return
+	}
+
+	for _, ref := range request.Entries {
+		if !validBatchType(ref.Type) {
+			e.WriteInvalidJsonResponse(w, fmt.Sprintf("Unknown type %s, use items, %s, sets or mounts.", ref.Type, strings.Join(batchItemTypes, ", ")))
+			return
+		}
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	utils.RequestsTotal.Inc()
+	utils.RequestsBatch.Inc()
+
+	response := APIBatch{
+		Entries: make([]APIBatchEntry, 0, len(request.Entries)),
+	}
+	for _, ref := range request.Entries {
+		renders := make([]any, len(langs))
+		for i, lang := range langs {
+			renders[i], err = resolveBatchEntry(ref, lang, expansions, txn)
+			if err != nil {
+				e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+				return
+			}
+		}
+
+		data := renders[0]
+		if localized && data != nil {
+			data = utils.Localize(renders, langs)
+		} else if data != nil {
+			data = utils.Fallback(r, data, func(lang string) any {
+				rendered, _ := resolveBatchEntry(ref, lang, expansions, txn) // the entry was just read without error
+				return rendered
+			})
+		}
+
+		response.Entries = append(response.Entries, APIBatchEntry{
+			Type:  ref.Type,
+			Id:    ref.Id,
+			Found: data != nil,
+			Data:  data,
+		})
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, response)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
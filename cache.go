@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	"github.com/dofusdude/doduapi/utils"
+)
+
+// CachePolicy describes how long clients and proxies may reuse the responses of a route group.
+type CachePolicy struct {
+	MaxAge time.Duration
+
+	// Validity returns the period in which a response cannot change apart from data updates, like an almanax day.
+	// When set, it replaces MaxAge and responses expire at the end of the period.
+	Validity func(r *http.Request, now time.Time) (start time.Time, end time.Time)
+
+	// Responses keeps rendered responses in memory, nil renders every request.
+	Responses *utils.ResponseCache
+}
+
+// almanaxDayValidity keeps almanax responses until the next midnight in the requested timezone, because ranges
+// without range[from] start today.
+func almanaxDayValidity(r *http.Request, now time.Time) (time.Time, time.Time) {
+	timezone := r.URL.Query().Get("timezone")
+	if timezone == "" {
+		timezone = "Europe/Paris"
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil { // the handler answers with an error, which is not cached
+		loc = time.UTC
+	}
+
+	now = now.In(loc)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// cacheEtag identifies a response without rendering it. The served data only changes with the generation, so the
+// generation and everything that selects a representation of it are enough. The language is negotiated before on
+// routes without {lang}.
+func cacheEtag(r *http.Request, generation int64, validFrom time.Time) string {
+	lang, _ := r.Context().Value("lang").(string)
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d\n%d\n%s\n%s\n%s\n%s", generation, validFrom.Unix(), r.URL.Path, r.URL.Query().Encode(), r.Header.Get("Accept"), lang)
+	return fmt.Sprintf(`W/"%x"`, hash.Sum64())
+}
+
+// etagMatches compares an If-None-Match header weakly, as RFC 9110 requires for GET and HEAD.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified evaluates the conditional headers. If-Modified-Since is ignored when If-None-Match is present.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// responseCacheKey selects a rendered response. The language and the format are negotiated before, so equivalent
+// Accept and Accept-Language headers share an entry.
+func responseCacheKey(r *http.Request, generation int64) string {
+	format, _ := r.Context().Value("format").(string)
+	lang, _ := r.Context().Value("lang").(string)
+	return fmt.Sprintf("%d %s %s %s?%s", generation, format, lang, r.URL.Path, r.URL.Query().Encode())
+}
+
+// cacheResponseWriter drops the validators from responses that are not successful, so errors are never revalidated
+// and server errors are not cached at all.
+type cacheResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	status      int
+
+	// body records the response for the response cache. It is nil when the response is not recorded or got too large.
+	body    []byte
+	maxBody int
+}
+
+func (c *cacheResponseWriter) WriteHeader(status int) {
+	if !c.wroteHeader {
+		c.wroteHeader = true
+		c.status = status
+		if status >= 300 {
+			c.Header().Del("ETag")
+			c.Header().Del("Last-Modified")
+		}
+		if status >= 500 {
+			c.Header().Set("Cache-Control", "no-store")
+			c.Header().Del("Expires")
+		}
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *cacheResponseWriter) Write(b []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	if c.body != nil {
+		if len(c.body)+len(b) > c.maxBody {
+			c.body = nil
+		} else {
+			c.body = append(c.body, b...)
+		}
+	}
+	return c.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the flusher of the underlying writer for streamed listings.
+func (c *cacheResponseWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// cachePolicy sets Cache-Control, ETag and Last-Modified for GET requests and answers conditional requests with
+// 304 Not Modified before the handler runs. With a response cache, successful responses are rendered once per
+// generation.
+func cachePolicy(policy CachePolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			now := time.Now()
+			lastModified := config.CurrentVersion.UpdateStamp
+			maxAge := policy.MaxAge
+			expires := now.Add(maxAge)
+			var validFrom time.Time
+			if policy.Validity != nil {
+				validFrom, expires = policy.Validity(r, now)
+				maxAge = expires.Sub(now)
+				if validFrom.After(lastModified) {
+					lastModified = validFrom
+				}
+			}
+
+			generation := database.Version.Generation.Load()
+			etag := cacheEtag(r, generation, validFrom)
+
+			header := w.Header()
+			if maxAge > 0 {
+				header.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+			} else {
+				header.Set("Cache-Control", "no-cache")
+			}
+			header.Set("Expires", expires.UTC().Format(http.TimeFormat))
+			header.Set("ETag", etag)
+			if !lastModified.IsZero() {
+				header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+			}
+
+			// answers without the handler only count in RequestsTotal, the per endpoint counters are in the handlers
+			if notModified(r, etag, lastModified) {
+				utils.RequestsTotal.Inc()
+				header.Add("Vary", "Accept")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			writer := &cacheResponseWriter{ResponseWriter: w}
+			if policy.Responses == nil {
+				next.ServeHTTP(writer, r)
+				return
+			}
+
+			key := responseCacheKey(r, generation)
+			if cached, ok := policy.Responses.Get(key); ok {
+				utils.RequestsTotal.Inc()
+				header.Add("Vary", "Accept")
+				header.Set("Content-Type", cached.ContentType)
+				_, _ = w.Write(cached.Body)
+				return
+			}
+
+			writer.body = []byte{}
+			writer.maxBody = policy.Responses.MaxEntrySize()
+			next.ServeHTTP(writer, r)
+
+			// a client that went away leaves the response incomplete
+			if r.Method == http.MethodGet && writer.status == http.StatusOK && writer.body != nil && r.Context().Err() == nil {
+				policy.Responses.Put(key, utils.CachedResponse{ContentType: header.Get("Content-Type"), Body: writer.body})
+			}
+		})
+	}
+}
+
+// staticCache only sets Cache-Control. The file server validates with the modification times of the files itself.
+func staticCache(maxAge time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
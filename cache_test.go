@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	"github.com/dofusdude/doduapi/utils"
+)
+
+func TestCachePolicyConditionalRequests(t *testing.T) {
+	config.CurrentVersion.UpdateStamp = time.Now().Add(-time.Hour)
+	database.Version.Generation.Store(1)
+
+	calls := 0
+	handler := cachePolicy(CachePolicy{MaxAge: time.Minute})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/en/items/equipment/1", nil))
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" || w.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Fatal("Expected a cacheable response with an ETag, got ", w.Code, w.Header())
+	}
+
+	r := httptest.NewRequest("GET", "/en/items/equipment/1", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified || calls != 1 {
+		t.Error("Expected 304 without calling the handler, got ", w.Code, " after ", calls, " calls")
+	}
+
+	r = httptest.NewRequest("GET", "/en/items/equipment/1", nil)
+	r.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Error("Expected 304 for If-Modified-Since after the update, got ", w.Code)
+	}
+
+	database.Version.Generation.Store(2)
+	r = httptest.NewRequest("GET", "/en/items/equipment/1", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Error("Expected a new generation to invalidate the ETag, got ", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/en/items/equipment/1?fail=1", nil))
+	if w.Header().Get("ETag") != "" || w.Header().Get("Cache-Control") != "no-store" {
+		t.Error("Expected server errors to be uncacheable, got ", w.Header())
+	}
+}
+
+func TestAlmanaxDayValidity(t *testing.T) {
+	r := httptest.NewRequest("GET", "/en/almanax?timezone=America/New_York", nil)
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no timezone data")
+	}
+
+	now := time.Date(2024, 3, 10, 23, 30, 0, 0, loc)
+	start, end := almanaxDayValidity(r, now)
+	if !start.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, loc)) || !end.Equal(time.Date(2024, 3, 11, 0, 0, 0, 0, loc)) {
+		t.Error("Expected the day of ", now, ", got ", start, " until ", end)
+	}
+}
+
+func TestCachePolicyResponseCache(t *testing.T) {
+	database.Version.Generation.Store(1)
+	responses := utils.NewResponseCache(1 << 20)
+
+	calls := 0
+	handler := cachePolicy(CachePolicy{Responses: responses})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"Hat"}`))
+	}))
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/en/items/equipment/1", nil))
+		if w.Body.String() != `{"name":"Hat"}` || w.Header().Get("Content-Type") != "application/json" {
+			t.Error("Expected the rendered response, got ", w.Body.String(), w.Header())
+		}
+	}
+	if calls != 1 {
+		t.Error("Expected the second request to be served from the cache, rendered ", calls, " times")
+	}
+
+	database.Version.Generation.Store(2)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/en/items/equipment/1", nil))
+	if calls != 2 {
+		t.Error("Expected a new generation to render again, rendered ", calls, " times")
+	}
+}
+
+func TestResponseCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	responses := utils.NewResponseCache(400)
+	body := make([]byte, 90)
+
+	responses.Put("a", utils.CachedResponse{Body: body})
+	responses.Put("b", utils.CachedResponse{Body: body})
+	responses.Put("c", utils.CachedResponse{Body: body})
+	responses.Get("a")
+	responses.Put("d", utils.CachedResponse{Body: body})
+	responses.Put("e", utils.CachedResponse{Body: body})
+
+	for key, expected := range map[string]bool{"a": true, "b": false, "c": true, "d": true, "e": true} {
+		if _, ok := responses.Get(key); ok != expected {
+			t.Error("Expected ", key, " cached to be ", expected)
+		}
+	}
+
+	responses.Put("big", utils.CachedResponse{Body: make([]byte, 200)})
+	if _, ok := responses.Get("big"); ok {
+		t.Error("Expected entries above the maximum entry size to be skipped")
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressibleContentTypes are the media types worth compressing. Images are already compressed.
+var compressibleContentTypes = map[string]bool{
+	"application/json":     true,
+	"application/x-ndjson": true,
+	"application/msgpack":  true,
+	"text/csv":             true,
+	"text/html":            true,
+	"text/plain":           true,
+}
+
+type compressionEncoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+type compressionEncoding struct {
+	name string
+	pool sync.Pool
+}
+
+// compressionEncodings are ordered by preference for clients that accept several with the same quality.
+var compressionEncodings = []*compressionEncoding{
+	{name: "br", pool: sync.Pool{New: func() any {
+		return brotli.NewWriterLevel(nil, 5)
+	}}},
+	{name: "zstd", pool: sync.Pool{New: func() any {
+		encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return encoder
+	}}},
+	{name: "gzip", pool: sync.Pool{New: func() any {
+		return gzip.NewWriter(nil)
+	}}},
+}
+
+// negotiateEncoding picks the content coding from an Accept-Encoding header, nil means identity.
+func negotiateEncoding(acceptEncoding string) *compressionEncoding {
+	qualities := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if key == "q" {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		qualities[name] = quality
+	}
+
+	var best *compressionEncoding
+	bestQuality := 0.0
+	for _, encoding := range compressionEncodings {
+		quality, ok := qualities[encoding.name]
+		if !ok {
+			quality = qualities["*"]
+		}
+		if quality > bestQuality {
+			best = encoding
+			bestQuality = quality
+		}
+	}
+	return best
+}
+
+// compressWriter holds back the first minSize bytes. Smaller responses are sent as they are, because compressing
+// them costs more than it saves.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding *compressionEncoding
+	minSize  int
+	status   int
+	buffer   []byte
+	started  bool
+	encoder  compressionEncoder
+}
+
+func (c *compressWriter) WriteHeader(status int) {
+	if c.started {
+		c.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if c.status == 0 {
+		c.status = status
+	}
+}
+
+func (c *compressWriter) Write(b []byte) (int, error) {
+	if c.started {
+		if c.encoder != nil {
+			return c.encoder.Write(b)
+		}
+		return c.ResponseWriter.Write(b)
+	}
+
+	c.buffer = append(c.buffer, b...)
+	if len(c.buffer) >= c.minSize {
+		if err := c.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// FlushError ends the buffering. Responses flushed early are streamed and therefore compressed regardless of the size.
+func (c *compressWriter) FlushError() error {
+	if !c.started {
+		if err := c.start(true); err != nil {
+			return err
+		}
+	}
+	if c.encoder != nil {
+		if err := c.encoder.Flush(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(c.ResponseWriter).Flush()
+}
+
+func (c *compressWriter) Flush() {
+	_ = c.FlushError()
+}
+
+func (c *compressWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+func (c *compressWriter) compressible() bool {
+	if c.status == http.StatusNoContent || c.status == http.StatusNotModified || c.status < http.StatusOK {
+		return false
+	}
+	if c.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(c.Header().Get("Content-Type"))
+	return err == nil && compressibleContentTypes[mediaType]
+}
+
+func (c *compressWriter) start(compress bool) error {
+	c.started = true
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+
+	if compress && c.compressible() {
+		c.Header().Set("Content-Encoding", c.encoding.name)
+		c.Header().Del("Content-Length")
+		c.encoder = c.encoding.pool.Get().(compressionEncoder)
+		c.encoder.Reset(c.ResponseWriter)
+	}
+	c.ResponseWriter.WriteHeader(c.status)
+
+	buffered := c.buffer
+	c.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	_, err := c.Write(buffered)
+	return err
+}
+
+func (c *compressWriter) close() error {
+	if !c.started {
+		if c.status == 0 && len(c.buffer) == 0 {
+			return nil // nothing written, net/http answers with an empty 200
+		}
+		return c.start(false)
+	}
+
+	if c.encoder == nil {
+		return nil
+	}
+	err := c.encoder.Close()
+	c.encoding.pool.Put(c.encoder)
+	c.encoder = nil
+	return err
+}
+
+// compress encodes responses of at least minSize bytes with brotli, zstd or gzip, whatever the client prefers.
+func compress(minSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == nil || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			writer := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+			next.ServeHTTP(writer, r)
+			_ = writer.close()
+		})
+	}
+}
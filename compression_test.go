@@ -0,0 +1,83 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := map[string]string{
+		"":                        "",
+		"identity":                "",
+		"gzip, deflate":           "gzip",
+		"gzip, deflate, br, zstd": "br",
+		"br;q=0.5, zstd":          "zstd",
+		"*":                       "br",
+		"*, br;q=0":               "zstd",
+	}
+
+	for accept, expected := range cases {
+		encoding := negotiateEncoding(accept)
+		name := ""
+		if encoding != nil {
+			name = encoding.name
+		}
+		if name != expected {
+			t.Error("Expected ", expected, " for ", accept, ", got ", name)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	body := "[" + strings.Repeat(`{"name":"Hat"},`, 200) + "{}]"
+	handler := compress(1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+		if r.URL.Query().Has("small") {
+			w.Write([]byte("{}"))
+			if r.URL.Query().Has("flush") {
+				http.NewResponseController(w).Flush()
+			}
+			return
+		}
+		w.Write([]byte(body[:512]))
+		w.Write([]byte(body[512:]))
+	}))
+
+	request := func(query string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/en/items/equipment/all?"+query, nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := request("type=application/json")
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("Expected a gzip response, got ", w.Header())
+	}
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil || string(decoded) != body {
+		t.Error("Expected the original body after decoding, got ", err, len(decoded))
+	}
+
+	if w = request("type=application/json&small"); w.Header().Get("Content-Encoding") != "" || w.Body.String() != "{}" {
+		t.Error("Expected small responses to stay uncompressed, got ", w.Header())
+	}
+
+	if w = request("type=application/json&small&flush"); w.Header().Get("Content-Encoding") != "gzip" {
+		t.Error("Expected flushed responses to be compressed, got ", w.Header())
+	}
+
+	if w = request("type=image/webp"); w.Header().Get("Content-Encoding") != "" || w.Body.String() != body {
+		t.Error("Expected images to stay uncompressed, got ", w.Header())
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+)
+
+type ConditionEvaluationRequest struct {
+	Characteristics map[int]int `json:"characteristics"` // element id from /meta/elements -> value
+}
+
+type ApiConditionEvaluationNode struct {
+	Condition *ApiCondition                 `json:"condition,omitempty"`
+	IsOperand bool                          `json:"is_operand"`
+	Relation  *string                       `json:"relation,omitempty"` // "and" or "or"
+	Passed    bool                          `json:"passed"`
+	Children  []*ApiConditionEvaluationNode `json:"children,omitempty"`
+}
+
+type ApiConditionEvaluation struct {
+	Passed     bool                        `json:"passed"`
+	Conditions *ApiConditionEvaluationNode `json:"conditions,omitempty"`
+	Failed     []string                    `json:"failed_conditions,omitempty" localized:"true"` // localized text of the failing leaves
+}
+
+func compareCondition(operator string, value int, required int) (bool, error) {
+	switch operator {
+	case "<":
+		return value < required, nil
+	case ">":
+		return value > required, nil
+	case "=":
+		return value == required, nil
+	case "!":
+		return value != required, nil
+	case "<=":
+		return value <= required, nil
+	case ">=":
+		return value >= required, nil
+	}
+	return false, fmt.Errorf("unknown operator %s", operator)
+}
+
+// evaluateConditionTree walks the mapped condition tree and annotates every node with its result.
+// Characteristics that are not given count as 0. The localized text of all failing leaves is appended to failed.
+func evaluateConditionTree(root *mapping.ConditionTreeNodeMapped, characteristics map[int]int, lang string, failed *[]string) *ApiConditionEvaluationNode {
+	if root == nil {
+		return nil
+	}
+
+	out := &ApiConditionEvaluationNode{
+		IsOperand: root.IsOperand,
+	}
+
+	if root.IsOperand {
+		if root.Value == nil {
+			out.Passed = true
+			return out
+		}
+
+		out.Condition = &ApiCondition{
+			Operator: root.Value.Operator,
+			IntValue: root.Value.Value,
+			Element: ApiConditionType{
+				Name: root.Value.Templated[lang],
+				Id:   root.Value.ElementId,
+			},
+		}
+
+		passed, err := compareCondition(root.Value.Operator, characteristics[root.Value.ElementId], root.Value.Value)
+		out.Passed = err == nil && passed
+		if !out.Passed {
+			*failed = append(*failed, root.Value.Templated[lang])
+		}
+		return out
+	}
+
+	out.Relation = root.Relation
+	isOr := root.Relation != nil && *root.Relation == "or"
+
+	// collect failing leaves of this subtree separately, an "or" node only reports them if no alternative passed
+	var subtreeFailed []string
+	out.Passed = !isOr || len(root.Children) == 0
+	out.Children = make([]*ApiConditionEvaluationNode, 0, len(root.Children))
+	for _, child := range root.Children {
+		childOut := evaluateConditionTree(child, characteristics, lang, &subtreeFailed)
+		if childOut == nil {
+			continue
+		}
+		out.Children = append(out.Children, childOut)
+		if isOr {
+			out.Passed = out.Passed || childOut.Passed
+		} else {
+			out.Passed = out.Passed && childOut.Passed
+		}
+	}
+
+	if !out.Passed {
+		*failed = append(*failed, subtreeFailed...)
+	}
+
+	return out
+}
+
+func EvaluateConditions(conditions *mapping.ConditionTreeNodeMapped, characteristics map[int]int, lang string) ApiConditionEvaluation {
+	var failed []string
+	tree := evaluateConditionTree(conditions, characteristics, lang, &failed)
+
+	evaluation := ApiConditionEvaluation{
+		Passed:     tree == nil || tree.Passed,
+		Conditions: tree,
+	}
+
+	if !evaluation.Passed {
+		evaluation.Failed = failed
+	}
+
+	return evaluation
+}
+
+func validateCharacteristics(characteristics map[int]int, txn *memdb.Txn) error {
+	for elementId := range characteristics {
+		exists, err := elementExists(elementId, txn)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("unknown element id: %d", elementId)
+		}
+	}
+	return nil
+}
+
+func EvaluateEquipmentConditionsHandler(w http.ResponseWriter, r *http.Request) {
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	var evaluationRequest ConditionEvaluationRequest
+	if err := json.NewDecoder(r.Body).Decode(&evaluationRequest); err != nil {
+		e.WriteInvalidJsonResponse(w, err.Error())
+		return
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	if err := validateCharacteristics(evaluationRequest.Characteristics, txn); err != nil {
+		e.WriteInvalidJsonResponse(w, "characteristics has invalid fields: "+err.Error())
+		return
+	}
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "equipment"), "id", ankamaId)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	if raw == nil {
+		e.WriteNotFoundResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", "item", strconv.Itoa(ankamaId)))
+		return
+	}
+
+	utils.RequestsTotal.Inc()
+	utils.RequestsItemsConditions.Inc()
+
+	item := raw.(*mapping.MappedMultilangItemUnity)
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		return EvaluateConditions(item.Conditions, evaluationRequest.Characteristics, lang)
+	}))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+
+	mapping "github.com/dofusdude/dodumap"
+)
+
+func conditionLeaf(elementId int, operator string, value int, text string) *mapping.ConditionTreeNodeMapped {
+	return &mapping.ConditionTreeNodeMapped{
+		IsOperand: true,
+		Value: &mapping.MappedMultilangCondition{
+			ElementId: elementId,
+			Operator:  operator,
+			Value:     value,
+			Templated: map[string]string{"en": text},
+		},
+	}
+}
+
+func conditionRelation(relation string, children ...*mapping.ConditionTreeNodeMapped) *mapping.ConditionTreeNodeMapped {
+	return &mapping.ConditionTreeNodeMapped{
+		IsOperand: false,
+		Relation:  &relation,
+		Children:  children,
+	}
+}
+
+func TestEvaluateConditionsAnd(t *testing.T) {
+	tree := conditionRelation("and",
+		conditionLeaf(1, ">", 149, "Level > 149"),
+		conditionLeaf(2, ">", 399, "Agility > 399"),
+	)
+
+	evaluation := EvaluateConditions(tree, map[int]int{1: 150, 2: 400}, "en")
+	if !evaluation.Passed {
+		t.Error("Expected passed, got ", evaluation.Failed)
+	}
+
+	evaluation = EvaluateConditions(tree, map[int]int{1: 150, 2: 300}, "en")
+	if evaluation.Passed {
+		t.Error("Expected failed, got passed")
+	}
+
+	if len(evaluation.Failed) != 1 || evaluation.Failed[0] != "Agility > 399" {
+		t.Error("Expected [Agility > 399], got ", evaluation.Failed)
+	}
+
+	if !evaluation.Conditions.Children[0].Passed || evaluation.Conditions.Children[1].Passed {
+		t.Error("Expected first child passed and second failed")
+	}
+}
+
+func TestEvaluateConditionsOr(t *testing.T) {
+	tree := conditionRelation("or",
+		conditionLeaf(1, ">", 199, "Level > 199"),
+		conditionRelation("and",
+			conditionLeaf(2, ">", 399, "Agility > 399"),
+			conditionLeaf(3, "=", 2, "Set items = 2"),
+		),
+	)
+
+	evaluation := EvaluateConditions(tree, map[int]int{1: 150, 2: 400, 3: 2}, "en")
+	if !evaluation.Passed {
+		t.Error("Expected passed, got ", evaluation.Failed)
+	}
+
+	if evaluation.Failed != nil {
+		t.Error("Expected no failed conditions for a passed tree, got ", evaluation.Failed)
+	}
+
+	evaluation = EvaluateConditions(tree, map[int]int{1: 150}, "en")
+	if evaluation.Passed {
+		t.Error("Expected failed, got passed")
+	}
+
+	if len(evaluation.Failed) != 3 {
+		t.Error("Expected 3 failed conditions, got ", evaluation.Failed)
+	}
+}
+
+func TestEvaluateConditionsEmpty(t *testing.T) {
+	evaluation := EvaluateConditions(nil, map[int]int{}, "en")
+	if !evaluation.Passed {
+		t.Error("Expected passed for an item without conditions")
+	}
+
+	if evaluation.Conditions != nil {
+		t.Error("Expected no condition tree, got ", evaluation.Conditions)
+	}
+}
+
+func TestEvaluateConditionsUnknownOperator(t *testing.T) {
+	evaluation := EvaluateConditions(conditionLeaf(1, "~", 1, "Unknown"), map[int]int{1: 1}, "en")
+	if evaluation.Passed {
+		t.Error("Expected unknown operators to fail")
+	}
+}
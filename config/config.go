@@ -8,11 +8,12 @@ import (
 )
 
 var (
-	Languages               = []string{"de", "en", "es", "fr", "pt"}
 	ItemImgResolutions      = []string{"64", "128"}
 	MountImgResolutions     = []string{"64", "256"}
 	ApiHostName             string
 	ApiPort                 string
+	GrpcPort                string
+	GrpcEnabled             bool
 	ApiScheme               string
 	DockerMountDataPath     string
 	MajorVersion            int
@@ -36,4 +37,20 @@ var (
 	CurrentVersion          utils.GameVersion // TODO remove, since not a fixed config param
 	ApiVersion              string
 	SkipAlmanax             bool
+	GraphqlMaxDepth         int
+	GraphqlMaxComplexity    int
+	CacheMaxAgeEncyclopedia time.Duration
+	CacheMaxAgeSearch       time.Duration
+	CacheMaxAgeMeta         time.Duration
+	CacheMaxAgeImages       time.Duration
+	CompressionMinSize      int
+	ResponseCacheSize       int
+	RateLimitSearch         int
+	RateLimitList           int
+	RateLimitAll            int
+	RateLimitAlmanax        int
+	RateLimitIpHeader       string
+	RateLimitTrustedHops    int
+	TranslationFallback     map[string][]string
+	DocsRedocUrl            string
 )
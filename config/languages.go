@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// languages are the languages of the loaded game data. They change with the data, so they are read with Languages.
+var languages atomic.Pointer[[]string]
+
+// Languages returns the sorted language codes of the loaded game data. It is empty until data was loaded.
+func Languages() []string {
+	if langs := languages.Load(); langs != nil {
+		return *langs
+	}
+	return nil
+}
+
+// SetLanguages replaces the supported languages, usually with the ones found in newly loaded game data.
+func SetLanguages(langs []string) {
+	sorted := slices.Clone(langs)
+	slices.Sort(sorted)
+	languages.Store(&sorted)
+}
+
+// defaultFallbackLanguage fills in missing translations of languages without a configured fallback chain.
+const defaultFallbackLanguage = "en"
+
+// ParseTranslationFallback reads fallback chains like "pt:es:en,de:en". Each chain starts with the language it
+// applies to, a chain with just the language disables the fallback for it.
+func ParseTranslationFallback(value string) (map[string][]string, error) {
+	chains := make(map[string][]string)
+	for _, chain := range strings.Split(value, ",") {
+		if chain = strings.TrimSpace(chain); chain == "" {
+			continue
+		}
+		langs := strings.Split(strings.ToLower(chain), ":")
+		for i := range langs {
+			if langs[i] = strings.TrimSpace(langs[i]); langs[i] == "" {
+				return nil, fmt.Errorf("empty language in fallback chain %s", chain)
+			}
+		}
+		if _, exists := chains[langs[0]]; exists {
+			return nil, fmt.Errorf("more than one fallback chain for %s", langs[0])
+		}
+		chains[langs[0]] = langs[1:]
+	}
+	return chains, nil
+}
+
+// FallbackLanguages returns the languages that fill in missing translations of lang, in order. Languages without a
+// chain in TranslationFallback fall back to English. Languages that the game data does not have are skipped.
+func FallbackLanguages(lang string) []string {
+	chain, ok := TranslationFallback[lang]
+	if !ok {
+		chain = []string{defaultFallbackLanguage}
+	}
+
+	available := Languages()
+	fallback := make([]string, 0, len(chain))
+	for _, fallbackLang := range chain {
+		if fallbackLang != lang && slices.Contains(available, fallbackLang) && !slices.Contains(fallback, fallbackLang) {
+			fallback = append(fallback, fallbackLang)
+		}
+	}
+	return fallback
+}
@@ -62,21 +62,29 @@ func (r *Repository) Deinit() {
 }
 
 func (r *Repository) GetAlmanaxByDateRangeAndNameID(from, to, nameID string) ([]MappedAlmanax, error) {
+	return r.getMappedAlmanax(`a.date >= ? AND a.date <= ? AND bt.name_id = ? AND a.deleted_at IS NULL`, from, to, nameID)
+}
+
+func (r *Repository) GetAlmanaxByDateRange(from, to string) ([]MappedAlmanax, error) {
+	return r.getMappedAlmanax(`a.date >= ? AND a.date <= ? AND a.deleted_at IS NULL`, from, to)
+}
+
+// getMappedAlmanax returns the almanax days matching the condition, ordered by date, with the texts in all languages.
+func (r *Repository) getMappedAlmanax(where string, args ...any) ([]MappedAlmanax, error) {
 	query := `
 		SELECT
 			a.id, a.bonus_id, a.tribute_id, a.date, a.reward_kamas, a.experience_ratio, a.optimal_level, a.duration, a.created_at, a.updated_at, a.deleted_at,
-			b.id, b.bonus_type_id, b.description_en, b.description_fr, b.description_es, b.description_de, b.description_pt,
-			bt.id, bt.name_id, bt.name_en, bt.name_fr, bt.name_es, bt.name_de, bt.name_pt,
-			t.id, t.item_name_en, t.item_name_fr, t.item_name_es, t.item_name_de, t.item_name_pt,
-			t.item_ankama_id, t.item_category_id, t.item_doduapi_uri, t.quantity
+			b.id, b.bonus_type_id,
+			bt.id, bt.name_id,
+			t.id, t.item_ankama_id, t.item_category_id, t.item_doduapi_uri, t.quantity
 		FROM almanax AS a
 		JOIN bonus AS b ON a.bonus_id = b.id
 		JOIN bonus_types AS bt ON b.bonus_type_id = bt.id
 		JOIN tribute AS t ON a.tribute_id = t.id
-		WHERE a.date >= ? AND a.date <= ? AND bt.name_id = ? AND a.deleted_at IS NULL
+		WHERE ` + where + `
 		ORDER BY a.date ASC`
 
-	rows, err := r.Db.Query(query, from, to, nameID)
+	rows, err := r.Db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -91,12 +99,9 @@ func (r *Repository) GetAlmanaxByDateRangeAndNameID(from, to, nameID string) ([]
 		err := rows.Scan(
 			&denorm.Almanax.ID, &denorm.Almanax.BonusID, &denorm.Almanax.TributeID, &denorm.Almanax.Date,
 			&denorm.Almanax.RewardKamas, &denorm.Almanax.XpRatio, &denorm.Almanax.OptimalLvl, &denorm.Almanax.Duration, &denorm.Almanax.CreatedAt, &denorm.Almanax.UpdatedAt, &deletedAt,
-			&denorm.Bonus.ID, &denorm.Bonus.BonusTypeID, &denorm.Bonus.DescriptionEn, &denorm.Bonus.DescriptionFr,
-			&denorm.Bonus.DescriptionEs, &denorm.Bonus.DescriptionDe, &denorm.Bonus.DescriptionPt,
-			&denorm.BonusType.ID, &denorm.BonusType.NameID, &denorm.BonusType.NameEn, &denorm.BonusType.NameFr,
-			&denorm.BonusType.NameEs, &denorm.BonusType.NameDe, &denorm.BonusType.NamePt,
-			&denorm.Tribute.ID, &denorm.Tribute.ItemNameEn, &denorm.Tribute.ItemNameFr, &denorm.Tribute.ItemNameEs, &denorm.Tribute.ItemNameDe, &denorm.Tribute.ItemNamePt,
-			&denorm.Tribute.ItemAnkamaID, &denorm.Tribute.ItemCategoryId,
+			&denorm.Bonus.ID, &denorm.Bonus.BonusTypeID,
+			&denorm.BonusType.ID, &denorm.BonusType.NameID,
+			&denorm.Tribute.ID, &denorm.Tribute.ItemAnkamaID, &denorm.Tribute.ItemCategoryId,
 			&denorm.Tribute.ItemDoduapiUri, &denorm.Tribute.Quantity)
 
 		if err != nil {
@@ -113,86 +118,67 @@ func (r *Repository) GetAlmanaxByDateRangeAndNameID(from, to, nameID string) ([]
 		return nil, err
 	}
 
-	return result, nil
-}
-
-func (r *Repository) GetAlmanaxByDateRange(from, to string) ([]MappedAlmanax, error) {
-	query := `
-		SELECT
-			a.id, a.bonus_id, a.tribute_id, a.date, a.reward_kamas, a.experience_ratio, a.optimal_level, a.duration, a.created_at, a.updated_at, a.deleted_at,
-			b.id, b.bonus_type_id, b.description_en, b.description_fr, b.description_es, b.description_de, b.description_pt,
-			bt.id, bt.name_id, bt.name_en, bt.name_fr, bt.name_es, bt.name_de, bt.name_pt,
-			t.id, t.item_name_en, t.item_name_fr, t.item_name_es, t.item_name_de, t.item_name_pt,
-			t.item_ankama_id, t.item_category_id, t.item_doduapi_uri, t.quantity
-		FROM almanax AS a
-		JOIN bonus AS b ON a.bonus_id = b.id
-		JOIN bonus_types AS bt ON b.bonus_type_id = bt.id
-		JOIN tribute AS t ON a.tribute_id = t.id
-		WHERE a.date >= ? AND a.date <= ? AND a.deleted_at IS NULL
-		ORDER BY a.date ASC`
+	bonusIds := make([]int64, len(result))
+	bonusTypeIds := make([]int64, len(result))
+	tributeIds := make([]int64, len(result))
+	for i, denorm := range result {
+		bonusIds[i] = denorm.Bonus.ID
+		bonusTypeIds[i] = denorm.BonusType.ID
+		tributeIds[i] = denorm.Tribute.ID
+	}
 
-	rows, err := r.Db.Query(query, from, to)
+	descriptions, err := r.getTranslations(translationEntityBonus, translationFieldDescription, bonusIds)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var result []MappedAlmanax
-
-	for rows.Next() {
-		var denorm MappedAlmanax
-		var deletedAt sql.NullTime
-
-		err := rows.Scan(
-			&denorm.Almanax.ID, &denorm.Almanax.BonusID, &denorm.Almanax.TributeID, &denorm.Almanax.Date,
-			&denorm.Almanax.RewardKamas, &denorm.Almanax.XpRatio, &denorm.Almanax.OptimalLvl, &denorm.Almanax.Duration, &denorm.Almanax.CreatedAt, &denorm.Almanax.UpdatedAt, &deletedAt,
-			&denorm.Bonus.ID, &denorm.Bonus.BonusTypeID, &denorm.Bonus.DescriptionEn, &denorm.Bonus.DescriptionFr,
-			&denorm.Bonus.DescriptionEs, &denorm.Bonus.DescriptionDe, &denorm.Bonus.DescriptionPt,
-			&denorm.BonusType.ID, &denorm.BonusType.NameID, &denorm.BonusType.NameEn, &denorm.BonusType.NameFr,
-			&denorm.BonusType.NameEs, &denorm.BonusType.NameDe, &denorm.BonusType.NamePt,
-			&denorm.Tribute.ID, &denorm.Tribute.ItemNameEn, &denorm.Tribute.ItemNameFr, &denorm.Tribute.ItemNameEs, &denorm.Tribute.ItemNameDe, &denorm.Tribute.ItemNamePt,
-			&denorm.Tribute.ItemAnkamaID, &denorm.Tribute.ItemCategoryId,
-			&denorm.Tribute.ItemDoduapiUri, &denorm.Tribute.Quantity)
-
-		if err != nil {
-			return nil, err
-		}
-		if deletedAt.Valid {
-			denorm.Almanax.DeletedAt = &deletedAt.Time
-		}
-
-		result = append(result, denorm)
+	bonusTypeNames, err := r.getTranslations(translationEntityBonusType, translationFieldName, bonusTypeIds)
+	if err != nil {
+		return nil, err
 	}
-
-	if err = rows.Err(); err != nil {
+	itemNames, err := r.getTranslations(translationEntityTribute, translationFieldItemName, tributeIds)
+	if err != nil {
 		return nil, err
 	}
 
+	for i := range result {
+		result[i].Bonus.Descriptions = descriptions[result[i].Bonus.ID]
+		result[i].BonusType.Names = bonusTypeNames[result[i].BonusType.ID]
+		result[i].Tribute.ItemNames = itemNames[result[i].Tribute.ID]
+	}
+
 	return result, nil
 }
 
 func (r *Repository) CreateBonus(bonus *Bonus) (int64, error) {
-	query := `INSERT INTO bonus (bonus_type_id, description_en, description_fr, description_es, description_de, description_pt, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))`
-	result, err := r.Db.Exec(query, bonus.BonusTypeID, bonus.DescriptionEn, bonus.DescriptionFr, bonus.DescriptionEs,
-		bonus.DescriptionDe, bonus.DescriptionPt)
+	query := `INSERT INTO bonus (bonus_type_id, created_at, updated_at)
+	          VALUES (?, datetime('now'), datetime('now'))`
+	result, err := r.Db.Exec(query, bonus.BonusTypeID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
 
-	return result.LastInsertId()
+	return id, r.setTranslations(translationEntityBonus, id, translationFieldDescription, bonus.Descriptions)
 }
 
 func (r *Repository) CreateTribute(tribute *Tribute) (int64, error) {
-	query := `INSERT INTO tribute (item_name_en, item_name_fr, item_name_es, item_name_de, item_name_pt, item_ankama_id, item_category_id, item_doduapi_uri, quantity, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))`
-	result, err := r.Db.Exec(query, tribute.ItemNameEn, tribute.ItemNameFr, tribute.ItemNameEs, tribute.ItemNameDe,
-		tribute.ItemNamePt, tribute.ItemAnkamaID, tribute.ItemCategoryId, tribute.ItemDoduapiUri, tribute.Quantity)
+	query := `INSERT INTO tribute (item_ankama_id, item_category_id, item_doduapi_uri, quantity, created_at, updated_at)
+	          VALUES (?, ?, ?, ?, datetime('now'), datetime('now'))`
+	result, err := r.Db.Exec(query, tribute.ItemAnkamaID, tribute.ItemCategoryId, tribute.ItemDoduapiUri, tribute.Quantity)
 	if err != nil {
 		return 0, err
 	}
 
-	return result.LastInsertId()
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, r.setTranslations(translationEntityTribute, id, translationFieldItemName, tribute.ItemNames)
 }
 
 func (r *Repository) CreateOrUpdate(date string, almanax *dodumap.MappedMultilangNPCAlmanaxUnity) (int64, error) {
@@ -203,32 +189,35 @@ func (r *Repository) CreateOrUpdate(date string, almanax *dodumap.MappedMultilan
 	if err == sql.ErrNoRows {
 		bonusTypeID, err = r.CreateBonusType(&BonusType{
 			NameID: bonusType,
-			NameEn: almanax.BonusType["en"],
-			NameFr: almanax.BonusType["fr"],
-			NameEs: almanax.BonusType["es"],
-			NameDe: almanax.BonusType["de"],
-			NamePt: almanax.BonusType["pt"],
+			Names:  almanax.BonusType,
 		})
 		if err != nil {
 			return -1, err
 		}
+	} else if err != nil {
+		return -1, err
+	} else if err = r.setTranslations(translationEntityBonusType, bonusTypeID, translationFieldName, almanax.BonusType); err != nil {
+		return -1, err
 	}
 
-	query = `SELECT id FROM bonus WHERE description_en = ? AND deleted_at IS NULL`
+	// bonuses are identified by their english description
+	query = `SELECT b.id FROM bonus AS b
+	         JOIN translations AS t ON t.entity = ? AND t.entity_id = b.id AND t.field = ? AND t.lang = 'en'
+	         WHERE t.value = ? AND b.deleted_at IS NULL`
 	var bonusID int64
-	err = r.Db.QueryRow(query, almanax.Bonus["en"]).Scan(&bonusID)
+	err = r.Db.QueryRow(query, translationEntityBonus, translationFieldDescription, almanax.Bonus["en"]).Scan(&bonusID)
 	if err == sql.ErrNoRows {
 		bonusID, err = r.CreateBonus(&Bonus{
-			BonusTypeID:   bonusTypeID,
-			DescriptionEn: almanax.Bonus["en"],
-			DescriptionFr: almanax.Bonus["fr"],
-			DescriptionEs: almanax.Bonus["es"],
-			DescriptionDe: almanax.Bonus["de"],
-			DescriptionPt: almanax.Bonus["pt"],
+			BonusTypeID:  bonusTypeID,
+			Descriptions: almanax.Bonus,
 		})
 		if err != nil {
 			return -1, err
 		}
+	} else if err != nil {
+		return -1, err
+	} else if err = r.setTranslations(translationEntityBonus, bonusID, translationFieldDescription, almanax.Bonus); err != nil {
+		return -1, err
 	}
 
 	query = `SELECT id FROM tribute WHERE item_ankama_id = ? AND quantity = ? AND deleted_at IS NULL`
@@ -252,11 +241,7 @@ func (r *Repository) CreateOrUpdate(date string, almanax *dodumap.MappedMultilan
 		}
 
 		tributeID, err = r.CreateTribute(&Tribute{
-			ItemNameEn:     almanax.Offering.ItemName["en"],
-			ItemNameFr:     almanax.Offering.ItemName["fr"],
-			ItemNameEs:     almanax.Offering.ItemName["es"],
-			ItemNameDe:     almanax.Offering.ItemName["de"],
-			ItemNamePt:     almanax.Offering.ItemName["pt"],
+			ItemNames:      almanax.Offering.ItemName,
 			ItemAnkamaID:   int64(almanax.Offering.ItemId),
 			ItemCategoryId: almanax.Offering.ItemCategoryId,
 			ItemDoduapiUri: itemApiUri,
@@ -265,6 +250,10 @@ func (r *Repository) CreateOrUpdate(date string, almanax *dodumap.MappedMultilan
 		if err != nil {
 			return -1, err
 		}
+	} else if err != nil {
+		return -1, err
+	} else if err = r.setTranslations(translationEntityTribute, tributeID, translationFieldItemName, almanax.Offering.ItemName); err != nil {
+		return -1, err
 	}
 
 	query = `SELECT id FROM almanax WHERE date = ? AND deleted_at IS NULL LIMIT 1`
@@ -329,14 +318,19 @@ func (r *Repository) UpdateFuture(data map[string]dodumap.MappedMultilangNPCAlma
 }
 
 func (r *Repository) CreateBonusType(bonusType *BonusType) (int64, error) {
-	query := `INSERT INTO bonus_types (name_id, name_en, name_fr, name_es, name_de, name_pt, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))`
-	result, err := r.Db.Exec(query, bonusType.NameID, bonusType.NameEn, bonusType.NameFr, bonusType.NameEs,
-		bonusType.NameDe, bonusType.NamePt)
+	query := `INSERT INTO bonus_types (name_id, created_at, updated_at)
+	          VALUES (?, datetime('now'), datetime('now'))`
+	result, err := r.Db.Exec(query, bonusType.NameID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
-	return result.LastInsertId()
+
+	return id, r.setTranslations(translationEntityBonusType, id, translationFieldName, bonusType.Names)
 }
 
 func enNameToId(enName string) string {
@@ -344,8 +338,7 @@ func enNameToId(enName string) string {
 }
 
 func (r *Repository) GetBonusTypes() ([]BonusType, error) {
-	query := `SELECT id, name_id, name_en, name_fr, name_es, name_de, name_pt
-	          FROM bonus_types WHERE deleted_at IS NULL`
+	query := `SELECT id, name_id FROM bonus_types WHERE deleted_at IS NULL`
 	rows, err := r.Db.Query(query)
 	if err != nil {
 		return nil, err
@@ -355,8 +348,7 @@ func (r *Repository) GetBonusTypes() ([]BonusType, error) {
 	result := make([]BonusType, 0)
 	for rows.Next() {
 		var bonusType BonusType
-		err := rows.Scan(&bonusType.ID, &bonusType.NameID, &bonusType.NameEn, &bonusType.NameFr, &bonusType.NameEs,
-			&bonusType.NameDe, &bonusType.NamePt)
+		err := rows.Scan(&bonusType.ID, &bonusType.NameID)
 		if err != nil {
 			return nil, err
 		}
@@ -367,5 +359,19 @@ func (r *Repository) GetBonusTypes() ([]BonusType, error) {
 		return nil, err
 	}
 
+	ids := make([]int64, len(result))
+	for i, bonusType := range result {
+		ids[i] = bonusType.ID
+	}
+
+	names, err := r.getTranslations(translationEntityBonusType, translationFieldName, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].Names = names[result[i].ID]
+	}
+
 	return result, nil
 }
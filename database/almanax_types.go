@@ -3,45 +3,33 @@ package database
 import "time"
 
 type BonusType struct {
-	ID        int64      `db:"id"`
-	NameID    string     `db:"name_id"`
-	NameEn    string     `db:"name_en"`
-	NameFr    string     `db:"name_fr"`
-	NameEs    string     `db:"name_es"`
-	NameDe    string     `db:"name_de"`
-	NamePt    string     `db:"name_pt"`
-	CreatedAt time.Time  `db:"created_at"`
-	UpdatedAt time.Time  `db:"updated_at"`
-	DeletedAt *time.Time `db:"deleted_at"`
+	ID        int64             `db:"id"`
+	NameID    string            `db:"name_id"`
+	Names     map[string]string // by language, stored in translations
+	CreatedAt time.Time         `db:"created_at"`
+	UpdatedAt time.Time         `db:"updated_at"`
+	DeletedAt *time.Time        `db:"deleted_at"`
 }
 
 type Bonus struct {
-	ID            int64      `db:"id"`
-	BonusTypeID   int64      `db:"bonus_type_id"`
-	DescriptionEn string     `db:"description_en"`
-	DescriptionFr string     `db:"description_fr"`
-	DescriptionEs string     `db:"description_es"`
-	DescriptionDe string     `db:"description_de"`
-	DescriptionPt string     `db:"description_pt"`
-	CreatedAt     time.Time  `db:"created_at"`
-	UpdatedAt     time.Time  `db:"updated_at"`
-	DeletedAt     *time.Time `db:"deleted_at"`
+	ID           int64             `db:"id"`
+	BonusTypeID  int64             `db:"bonus_type_id"`
+	Descriptions map[string]string // by language, stored in translations
+	CreatedAt    time.Time         `db:"created_at"`
+	UpdatedAt    time.Time         `db:"updated_at"`
+	DeletedAt    *time.Time        `db:"deleted_at"`
 }
 
 type Tribute struct {
-	ID             int64      `db:"id"`
-	ItemNameEn     string     `db:"item_name_en"`
-	ItemNameFr     string     `db:"item_name_fr"`
-	ItemNameEs     string     `db:"item_name_es"`
-	ItemNameDe     string     `db:"item_name_de"`
-	ItemNamePt     string     `db:"item_name_pt"`
-	ItemAnkamaID   int64      `db:"item_ankama_id"`
-	ItemCategoryId int        `db:"item_category_id"`
-	ItemDoduapiUri string     `db:"item_doduapi_uri"`
-	Quantity       int        `db:"quantity"`
-	CreatedAt      time.Time  `db:"created_at"`
-	UpdatedAt      time.Time  `db:"updated_at"`
-	DeletedAt      *time.Time `db:"deleted_at"`
+	ID             int64             `db:"id"`
+	ItemNames      map[string]string // by language, stored in translations
+	ItemAnkamaID   int64             `db:"item_ankama_id"`
+	ItemCategoryId int               `db:"item_category_id"`
+	ItemDoduapiUri string            `db:"item_doduapi_uri"`
+	Quantity       int               `db:"quantity"`
+	CreatedAt      time.Time         `db:"created_at"`
+	UpdatedAt      time.Time         `db:"updated_at"`
+	DeletedAt      *time.Time        `db:"deleted_at"`
 }
 
 type Almanax struct {
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AnonymousApiKeyId is the api_key_id of the usage of requests without a key.
+const AnonymousApiKeyId = 0
+
+type ApiKey struct {
+	ID              int64      `db:"id"`
+	KeyHash         string     `db:"key_hash"`
+	Prefix          string     `db:"prefix"`
+	Owner           string     `db:"owner"`
+	Contact         string     `db:"contact"`
+	RateLimitFactor float64    `db:"rate_limit_factor"`
+	CreatedAt       time.Time  `db:"created_at"`
+	RevokedAt       *time.Time `db:"revoked_at"`
+}
+
+type ApiKeyUsage struct {
+	ApiKeyID int64  `db:"api_key_id"`
+	Day      string `db:"day"`
+	Route    string `db:"route"`
+	Requests int64  `db:"requests"`
+}
+
+func (r *Repository) CreateApiKey(apiKey *ApiKey) (int64, error) {
+	query := `INSERT INTO api_keys (key_hash, prefix, owner, contact, rate_limit_factor, created_at)
+	          VALUES (?, ?, ?, ?, ?, datetime('now'))`
+	result, err := r.Db.Exec(query, apiKey.KeyHash, apiKey.Prefix, apiKey.Owner, apiKey.Contact, apiKey.RateLimitFactor)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func scanApiKey(row interface{ Scan(dest ...any) error }) (ApiKey, error) {
+	var apiKey ApiKey
+	var contact sql.NullString
+	var revokedAt sql.NullTime
+	err := row.Scan(&apiKey.ID, &apiKey.KeyHash, &apiKey.Prefix, &apiKey.Owner, &contact, &apiKey.RateLimitFactor,
+		&apiKey.CreatedAt, &revokedAt)
+	if err != nil {
+		return apiKey, err
+	}
+
+	apiKey.Contact = contact.String
+	if revokedAt.Valid {
+		apiKey.RevokedAt = &revokedAt.Time
+	}
+	return apiKey, nil
+}
+
+// GetApiKeyByHash returns the key with the given hash, nil if there is none. Revoked keys are returned as well.
+func (r *Repository) GetApiKeyByHash(keyHash string) (*ApiKey, error) {
+	query := `SELECT id, key_hash, prefix, owner, contact, rate_limit_factor, created_at, revoked_at
+	          FROM api_keys WHERE key_hash = ?`
+	apiKey, err := scanApiKey(r.Db.QueryRow(query, keyHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (r *Repository) GetApiKeys() ([]ApiKey, error) {
+	query := `SELECT id, key_hash, prefix, owner, contact, rate_limit_factor, created_at, revoked_at
+	          FROM api_keys ORDER BY id`
+	rows, err := r.Db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ApiKey, 0)
+	for rows.Next() {
+		apiKey, err := scanApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, apiKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RevokeApiKey revokes the key with the given id or prefix. It returns false if no active key matched. Prefixes are
+// not unique, so nothing is revoked if more than one active key matches.
+func (r *Repository) RevokeApiKey(idOrPrefix string) (bool, error) {
+	tx, err := r.Db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var matches int
+	query := `SELECT COUNT(*) FROM api_keys WHERE (CAST(id AS TEXT) = ? OR prefix = ?) AND revoked_at IS NULL`
+	if err = tx.QueryRow(query, idOrPrefix, idOrPrefix).Scan(&matches); err != nil {
+		return false, err
+	}
+	if matches == 0 {
+		return false, nil
+	}
+	if matches > 1 {
+		return false, fmt.Errorf("%s matches %d active keys, revoke by id", idOrPrefix, matches)
+	}
+
+	query = `UPDATE api_keys SET revoked_at = datetime('now')
+	         WHERE (CAST(id AS TEXT) = ? OR prefix = ?) AND revoked_at IS NULL`
+	if _, err = tx.Exec(query, idOrPrefix, idOrPrefix); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// AddApiKeyUsage adds the counted requests to the stored ones in a single transaction.
+func (r *Repository) AddApiKeyUsage(usage []ApiKeyUsage) error {
+	tx, err := r.Db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO api_key_usage (api_key_id, day, route, requests) VALUES (?, ?, ?, ?)
+	          ON CONFLICT (api_key_id, day, route) DO UPDATE SET requests = requests + excluded.requests`
+	for _, entry := range usage {
+		if _, err := tx.Exec(query, entry.ApiKeyID, entry.Day, entry.Route, entry.Requests); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetApiKeyUsage returns the usage of a key from the given day on, ordered by day and route.
+func (r *Repository) GetApiKeyUsage(apiKeyId int64, fromDay string) ([]ApiKeyUsage, error) {
+	query := `SELECT api_key_id, day, route, requests FROM api_key_usage
+	          WHERE api_key_id = ? AND day >= ? ORDER BY day, route`
+	rows, err := r.Db.Query(query, apiKeyId, fromDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]ApiKeyUsage, 0)
+	for rows.Next() {
+		var usage ApiKeyUsage
+		if err := rows.Scan(&usage.ApiKeyID, &usage.Day, &usage.Route, &usage.Requests); err != nil {
+			return nil, err
+		}
+		result = append(result, usage)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -1,6 +1,10 @@
 package database
 
 import (
+	"hash/fnv"
+	"sync/atomic"
+
+	"github.com/dofusdude/doduapi/utils"
 	"github.com/hashicorp/go-memdb"
 	"github.com/meilisearch/meilisearch-go"
 )
@@ -15,8 +19,17 @@ var Db *memdb.MemDB
 var Indexes map[string]SearchIndexes
 
 type VersionT struct {
-	Search bool
-	MemDb  bool
+	Search     bool
+	MemDb      bool
+	Generation atomic.Int64 // identifies the served data, changes with every red/blue switch
+}
+
+// NextGeneration derives the generation from the game version and the active tables, so every instance serving the
+// same data agrees on cursors and ETags, also after a restart.
+func (v *VersionT) NextGeneration(gameVersion string) {
+	hash := fnv.New64a()
+	hash.Write([]byte(gameVersion + "-" + utils.CurrentRedBlueVersionStr(v.MemDb)))
+	v.Generation.Store(int64(hash.Sum64()))
 }
 
 var Version VersionT
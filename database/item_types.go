@@ -0,0 +1,122 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ItemType is an entry of the item type registry. Its id never changes once assigned, unlike the order of the types
+// in the game data.
+type ItemType struct {
+	ID          int64             `db:"id"`
+	AnkamaID    int               `db:"ankama_id"`
+	Slug        string            `db:"slug"`
+	CategoryId  int               `db:"category_id"`
+	SuperTypeId int               `db:"super_type_id"`
+	Names       map[string]string // by language, stored in translations
+}
+
+// GetItemTypes returns the registered item types with their names, ordered by id.
+func (r *Repository) GetItemTypes() ([]ItemType, error) {
+	rows, err := r.Db.Query(`SELECT id, ankama_id, slug, category_id, super_type_id FROM item_types ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var itemTypes []ItemType
+	var ids []int64
+	for rows.Next() {
+		var itemType ItemType
+		if err := rows.Scan(&itemType.ID, &itemType.AnkamaID, &itemType.Slug, &itemType.CategoryId, &itemType.SuperTypeId); err != nil {
+			return nil, err
+		}
+		itemTypes = append(itemTypes, itemType)
+		ids = append(ids, itemType.ID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	names, err := r.getTranslations(translationEntityItemType, translationFieldName, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range itemTypes {
+		itemTypes[i].Names = names[itemTypes[i].ID]
+	}
+
+	return itemTypes, nil
+}
+
+// RegisterItemTypes adds new item types to the registry and updates the known ones, identified by their Ankama id.
+// New types get their id from preferredIds if it is still free, else the next id after all registered and preferred
+// ones. The given types are returned with their ids. Either all types are registered or none.
+func (r *Repository) RegisterItemTypes(itemTypes []ItemType, preferredIds map[string]int64) ([]ItemType, error) {
+	tx, err := r.Db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var nextId int64
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM item_types`).Scan(&nextId); err != nil {
+		return nil, err
+	}
+	for _, id := range preferredIds {
+		if id >= nextId {
+			nextId = id + 1
+		}
+	}
+
+	registered := make([]ItemType, len(itemTypes))
+	for i, itemType := range itemTypes {
+		err := tx.QueryRow(`SELECT id FROM item_types WHERE ankama_id = ?`, itemType.AnkamaID).Scan(&itemType.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			if itemType.ID, err = freeItemTypeId(tx, preferredIds, itemType.Slug, &nextId); err != nil {
+				return nil, err
+			}
+			_, err = tx.Exec(`INSERT INTO item_types (id, ankama_id, slug, category_id, super_type_id, created_at, updated_at)
+			                    VALUES (?, ?, ?, ?, ?, datetime('now'), datetime('now'))`,
+				itemType.ID, itemType.AnkamaID, itemType.Slug, itemType.CategoryId, itemType.SuperTypeId)
+			if err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, err
+		} else {
+			_, err = tx.Exec(`UPDATE item_types SET slug = ?, category_id = ?, super_type_id = ?, updated_at = datetime('now')
+			                    WHERE id = ? AND (slug != ? OR category_id != ? OR super_type_id != ?)`,
+				itemType.Slug, itemType.CategoryId, itemType.SuperTypeId, itemType.ID,
+				itemType.Slug, itemType.CategoryId, itemType.SuperTypeId)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err = writeTranslations(tx, translationEntityItemType, itemType.ID, translationFieldName, itemType.Names); err != nil {
+			return nil, err
+		}
+		registered[i] = itemType
+	}
+
+	return registered, tx.Commit()
+}
+
+// freeItemTypeId returns the preferred id of a new item type if no other type has it, else the next free id.
+func freeItemTypeId(tx *sql.Tx, preferredIds map[string]int64, slug string, nextId *int64) (int64, error) {
+	if id, ok := preferredIds[slug]; ok {
+		var taken bool
+		if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM item_types WHERE id = ?)`, id).Scan(&taken); err != nil {
+			return 0, err
+		}
+		if !taken {
+			return id, nil
+		}
+	}
+
+	id := *nextId
+	*nextId++
+	return id, nil
+}
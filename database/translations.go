@@ -0,0 +1,108 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// Entities and fields of the texts in the translations table.
+const (
+	translationEntityBonusType = "bonus_types"
+	translationEntityBonus     = "bonus"
+	translationEntityTribute   = "tribute"
+	translationEntityItemType  = "item_types"
+
+	translationFieldName        = "name"
+	translationFieldDescription = "description"
+	translationFieldItemName    = "item_name"
+)
+
+// execer is a *sql.DB or a *sql.Tx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// setTranslations stores the texts of a field by language. Existing texts are replaced, so languages that were
+// added to the game data are filled in for entities that already exist.
+func (r *Repository) setTranslations(entity string, entityId int64, field string, texts map[string]string) error {
+	return writeTranslations(r.Db, entity, entityId, field, texts)
+}
+
+// writeTranslations is setTranslations on a given connection or transaction.
+func writeTranslations(db execer, entity string, entityId int64, field string, texts map[string]string) error {
+	query := `INSERT INTO translations (entity, entity_id, field, lang, value) VALUES (?, ?, ?, ?, ?)
+	          ON CONFLICT (entity, entity_id, field, lang) DO UPDATE SET value = excluded.value`
+	for lang, text := range texts {
+		if text == "" {
+			continue
+		}
+		if _, err := db.Exec(query, entity, entityId, field, lang, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTranslations returns the texts of a field of the given entities by entity id and language.
+func (r *Repository) getTranslations(entity string, field string, entityIds []int64) (map[int64]map[string]string, error) {
+	result := make(map[int64]map[string]string, len(entityIds))
+	if len(entityIds) == 0 {
+		return result, nil
+	}
+
+	args := []any{entity, field}
+	for _, id := range entityIds {
+		args = append(args, id)
+	}
+
+	query := `SELECT entity_id, lang, value FROM translations
+	          WHERE entity = ? AND field = ? AND entity_id IN (?` + strings.Repeat(", ?", len(entityIds)-1) + `)`
+	rows, err := r.Db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var lang, value string
+		if err := rows.Scan(&id, &lang, &value); err != nil {
+			return nil, err
+		}
+		if result[id] == nil {
+			result[id] = make(map[string]string)
+		}
+		result[id][lang] = value
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetAlmanaxLanguages returns the sorted languages of the stored almanax texts.
+func (r *Repository) GetAlmanaxLanguages() ([]string, error) {
+	rows, err := r.Db.Query(`SELECT DISTINCT lang FROM translations WHERE entity IN (?, ?, ?) ORDER BY lang`,
+		translationEntityBonusType, translationEntityBonus, translationEntityTribute)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]string, 0)
+	for rows.Next() {
+		var lang string
+		if err := rows.Scan(&lang); err != nil {
+			return nil, err
+		}
+		result = append(result, lang)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
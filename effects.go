@@ -0,0 +1,281 @@
+package main
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+)
+
+const (
+	EffectSignPositive = "positive"
+	EffectSignNegative = "negative"
+)
+
+var effectNumberRe = regexp.MustCompile(`\d+`)
+
+// EffectItemTypeRange holds the values an effect was seen with on the items of one item type.
+type EffectItemTypeRange struct {
+	ItemType *ItemTypeDbEntry
+	Count    int
+	Min      int
+	Max      int
+}
+
+// EffectDbEntry is an effect of the catalogue, collected from the effects of all items of the current data.
+type EffectDbEntry struct {
+	Id        int // element id, as in /meta/elements
+	Key       string
+	Names     map[string]string
+	Templates map[string]string
+	IsMeta    bool
+	IsActive  bool
+	Sign      string
+	ItemTypes []*EffectItemTypeRange // ordered by item type id
+
+	// collection state, see effectCatalogue.add
+	byItemType     map[int]*EffectItemTypeRange
+	negative       int
+	positive       int
+	templateSample int
+}
+
+// effectKey turns the english element name into a machine key, for example vitality or percent-critical.
+func effectKey(name string) string {
+	var key strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(strings.ReplaceAll(name, "%", " percent ")) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if separate && key.Len() > 0 {
+				key.WriteByte('-')
+			}
+			key.WriteRune(r)
+			separate = false
+		} else {
+			separate = true
+		}
+	}
+	return key.String()
+}
+
+// effectValues returns the values an effect shows in its text. Meta effects have none, fixed ones only a minimum.
+func effectValues(effect *mapping.MappedMultilangEffect) []int {
+	if effect.IsMeta || effect.MinMaxIrrelevant == -2 {
+		return nil
+	}
+	if effect.MinMaxIrrelevant <= -1 || effect.Max == 0 || effect.Max == effect.Min {
+		return []int{effect.Min}
+	}
+	return []int{effect.Min, effect.Max}
+}
+
+// effectTemplate replaces the values in a formatted effect text with #1 and #2, in the order they appear.
+func effectTemplate(templated string, values []int) string {
+	var template strings.Builder
+	last := 0
+	next := 0
+	for _, match := range effectNumberRe.FindAllStringIndex(templated, -1) {
+		if next < len(values) && templated[match[0]:match[1]] == strconv.Itoa(max(values[next], -values[next])) {
+			template.WriteString(templated[last:match[0]])
+			template.WriteString("#" + strconv.Itoa(next+1))
+			last = match[1]
+			next++
+		}
+	}
+	template.WriteString(templated[last:])
+	return template.String()
+}
+
+// effectCatalogue collects the effects of the items by element id.
+type effectCatalogue map[int]*EffectDbEntry
+
+func (catalogue effectCatalogue) add(item *mapping.MappedMultilangItemUnity, itemType *ItemTypeDbEntry) {
+	for i := range item.Effects {
+		effect := &item.Effects[i]
+		entry, ok := catalogue[effect.ElementId]
+		if !ok {
+			entry = &EffectDbEntry{
+				Id:         effect.ElementId,
+				Names:      effect.Type,
+				IsMeta:     effect.IsMeta,
+				IsActive:   effect.Active,
+				byItemType: make(map[int]*EffectItemTypeRange),
+			}
+			catalogue[effect.ElementId] = entry
+		}
+
+		values := effectValues(effect)
+
+		// the template comes from the effect that shows the most values, so #1 and #2 are known
+		if entry.Templates == nil || len(values) > entry.templateSample {
+			entry.Templates = make(map[string]string, len(effect.Templated))
+			for lang, templated := range effect.Templated {
+				entry.Templates[lang] = effectTemplate(templated, values)
+			}
+			entry.templateSample = len(values)
+		}
+
+		itemTypeRange, ok := entry.byItemType[itemType.AnkamaID]
+		if !ok {
+			itemTypeRange = &EffectItemTypeRange{ItemType: itemType}
+			entry.byItemType[itemType.AnkamaID] = itemTypeRange
+		}
+		for j, value := range values {
+			if value < 0 {
+				entry.negative++
+			} else {
+				entry.positive++
+			}
+			first := itemTypeRange.Count == 0 && j == 0
+			if first || value < itemTypeRange.Min {
+				itemTypeRange.Min = value
+			}
+			if first || value > itemTypeRange.Max {
+				itemTypeRange.Max = value
+			}
+		}
+		itemTypeRange.Count++
+	}
+}
+
+// insert finishes the collected effects and inserts them into the in-memory db. The item types need their registry
+// ids already.
+func (catalogue effectCatalogue) insert(txn *memdb.Txn) error {
+	elementNames := make(map[int]string)
+	if config.PersistedElements.Entries != nil {
+		it := config.PersistedElements.Entries.Iterator()
+		for it.Next() {
+			elementNames[it.Key().(int)] = it.Value().(string)
+		}
+	}
+
+	ids := make([]int, 0, len(catalogue))
+	for id := range catalogue {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	keys := make(map[string]bool, len(catalogue))
+	for _, id := range ids {
+		entry := catalogue[id]
+
+		name, ok := elementNames[id]
+		if !ok {
+			name = entry.Names["en"]
+		}
+		entry.Key = effectKey(name)
+		if entry.Key == "" || keys[entry.Key] {
+			entry.Key = strings.TrimPrefix(entry.Key+"-"+strconv.Itoa(id), "-")
+		}
+		keys[entry.Key] = true
+
+		// malus effects often show positive values with a minus in their text
+		entry.Sign = EffectSignPositive
+		if entry.negative > entry.positive || strings.HasPrefix(entry.Templates["en"], "-") {
+			entry.Sign = EffectSignNegative
+		}
+
+		for _, itemTypeRange := range entry.byItemType {
+			entry.ItemTypes = append(entry.ItemTypes, itemTypeRange)
+		}
+		sort.Slice(entry.ItemTypes, func(i, j int) bool {
+			return entry.ItemTypes[i].ItemType.ID < entry.ItemTypes[j].ItemType.ID
+		})
+		entry.byItemType = nil
+
+		if err := txn.Insert("effects", entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type APIEffectItemType struct {
+	Id       int64  `json:"id"`
+	AnkamaId int    `json:"ankama_id"`
+	Slug     string `json:"slug"`
+	Name     string `json:"name" localized:"true"`
+	Count    int    `json:"count"`
+	Min      int    `json:"min"`
+	Max      int    `json:"max"`
+}
+
+type APIEffectCatalogueEntry struct {
+	Id        int                 `json:"id"`
+	Key       string              `json:"key"`
+	Name      string              `json:"name" localized:"true"`
+	IsMeta    bool                `json:"is_meta"`
+	IsActive  bool                `json:"is_active"`
+	Sign      string              `json:"sign"`
+	Template  string              `json:"template" localized:"true"`
+	ItemTypes []APIEffectItemType `json:"item_types"`
+}
+
+func RenderEffectCatalogueEntry(entry *EffectDbEntry, lang string) APIEffectCatalogueEntry {
+	itemTypes := make([]APIEffectItemType, len(entry.ItemTypes))
+	for i, itemTypeRange := range entry.ItemTypes {
+		itemTypes[i] = APIEffectItemType{
+			Id:       itemTypeRange.ItemType.ID,
+			AnkamaId: itemTypeRange.ItemType.AnkamaID,
+			Slug:     itemTypeRange.ItemType.Slug,
+			Name:     itemTypeRange.ItemType.Names[lang],
+			Count:    itemTypeRange.Count,
+			Min:      itemTypeRange.Min,
+			Max:      itemTypeRange.Max,
+		}
+	}
+
+	return APIEffectCatalogueEntry{
+		Id:        entry.Id,
+		Key:       entry.Key,
+		Name:      entry.Names[lang],
+		IsMeta:    entry.IsMeta,
+		IsActive:  entry.IsActive,
+		Sign:      entry.Sign,
+		Template:  entry.Templates[lang],
+		ItemTypes: itemTypes,
+	}
+}
+
+func ListEffects(w http.ResponseWriter, r *http.Request) {
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get("effects", "id")
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	var effects []*EffectDbEntry
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		effects = append(effects, obj.(*EffectDbEntry))
+	}
+
+	if len(effects) == 0 {
+		e.WriteNotFoundResponse(w, "No effects found.")
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		rendered := make([]APIEffectCatalogueEntry, len(effects))
+		for i, effect := range effects {
+			rendered[i] = RenderEffectCatalogueEntry(effect, lang)
+		}
+		return rendered
+	}))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+)
+
+func TestEffectCatalogue(t *testing.T) {
+	hat := newItemTypeEntry(mapping.MappedMultilangItemTypeUnity{ItemTypeId: 16, Name: map[string]string{"en": "Hat"}})
+	hat.ID = 3
+	ring := newItemTypeEntry(mapping.MappedMultilangItemTypeUnity{ItemTypeId: 9, Name: map[string]string{"en": "Ring"}})
+	ring.ID = 1
+
+	vitality := func(min int, max int) mapping.MappedMultilangEffect {
+		return mapping.MappedMultilangEffect{
+			Min:       min,
+			Max:       max,
+			ElementId: 11,
+			Type:      map[string]string{"en": "Vitality", "fr": "Vitalité"},
+			Templated: map[string]string{"en": fmt.Sprintf("%d to %d Vitality", min, max), "fr": fmt.Sprintf("%d à %d Vitalité", min, max)},
+		}
+	}
+	malus := mapping.MappedMultilangEffect{
+		Min:       20,
+		ElementId: 157,
+		Type:      map[string]string{"en": "Vitality"},
+		Templated: map[string]string{"en": "-20 Vitality"},
+	}
+
+	catalogue := make(effectCatalogue)
+	catalogue.add(&mapping.MappedMultilangItemUnity{Effects: []mapping.MappedMultilangEffect{vitality(10, 0), malus}}, hat)
+	catalogue.add(&mapping.MappedMultilangItemUnity{Effects: []mapping.MappedMultilangEffect{vitality(31, 40)}}, hat)
+	catalogue.add(&mapping.MappedMultilangItemUnity{Effects: []mapping.MappedMultilangEffect{vitality(1, 5)}}, ring)
+
+	db, err := memdb.NewMemDB(GetMemDBSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn := db.Txn(true)
+	if err = catalogue.insert(txn); err != nil {
+		t.Fatal(err)
+	}
+	txn.Commit()
+
+	raw, err := db.Txn(false).First("effects", "id", 11)
+	if err != nil || raw == nil {
+		t.Fatal("Expected the vitality effect, got ", err)
+	}
+	effect := RenderEffectCatalogueEntry(raw.(*EffectDbEntry), "fr")
+	if effect.Key != "vitality" || effect.Sign != EffectSignPositive || effect.Template != "#1 à #2 Vitalité" {
+		t.Error("Expected key, sign and template of vitality, got ", effect)
+	}
+	if len(effect.ItemTypes) != 2 || effect.ItemTypes[0].Slug != "ring" || effect.ItemTypes[1].Min != 10 || effect.ItemTypes[1].Max != 40 || effect.ItemTypes[1].Count != 2 {
+		t.Error("Expected the observed ranges by item type, got ", effect.ItemTypes)
+	}
+
+	raw, _ = db.Txn(false).First("effects", "id", 157)
+	if effect := raw.(*EffectDbEntry); effect.Key != "vitality-157" || effect.Sign != EffectSignNegative || effect.Templates["en"] != "-#1 Vitality" {
+		t.Error("Expected a negative effect with a unique key, got ", effect)
+	}
+}
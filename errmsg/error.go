@@ -25,6 +25,18 @@ var (
 
 	ERR_NOT_FOUND         = "NOT_FOUND"
 	ERR_NOT_FOUND_MESSAGE = "The requested resource was not found."
+
+	ERR_NOT_ACCEPTABLE         = "NOT_ACCEPTABLE"
+	ERR_NOT_ACCEPTABLE_MESSAGE = "The requested response format is not supported. Please use the format query parameter or another Accept header."
+
+	ERR_STALE_CURSOR         = "STALE_CURSOR"
+	ERR_STALE_CURSOR_MESSAGE = "The data changed since the cursor was issued. Please restart pagination with an empty page[after]."
+
+	ERR_INVALID_API_KEY         = "INVALID_API_KEY"
+	ERR_INVALID_API_KEY_MESSAGE = "The API key you provided is unknown or was revoked. Remove it to use the API anonymously."
+
+	ERR_RATE_LIMITED         = "RATE_LIMITED"
+	ERR_RATE_LIMITED_MESSAGE = "Too many requests. Please slow down and retry after the time given in the Retry-After header."
 )
 
 type ApiError struct {
@@ -55,6 +67,22 @@ func WriteInvalidQueryResponse(w http.ResponseWriter, details string) {
 	WriteErrorResponse(w, http.StatusBadRequest, ERR_INVALID_QUERY_VALUE, ERR_INVALID_QUERY_MESSAGE, details)
 }
 
+func WriteStaleCursorResponse(w http.ResponseWriter, details string) {
+	WriteErrorResponse(w, http.StatusConflict, ERR_STALE_CURSOR, ERR_STALE_CURSOR_MESSAGE, details)
+}
+
+func WriteNotAcceptableResponse(w http.ResponseWriter, details string) {
+	WriteErrorResponse(w, http.StatusNotAcceptable, ERR_NOT_ACCEPTABLE, ERR_NOT_ACCEPTABLE_MESSAGE, details)
+}
+
+func WriteInvalidApiKeyResponse(w http.ResponseWriter, details string) {
+	WriteErrorResponse(w, http.StatusUnauthorized, ERR_INVALID_API_KEY, ERR_INVALID_API_KEY_MESSAGE, details)
+}
+
+func WriteTooManyRequestsResponse(w http.ResponseWriter, details string) {
+	WriteErrorResponse(w, http.StatusTooManyRequests, ERR_RATE_LIMITED, ERR_RATE_LIMITED_MESSAGE, details)
+}
+
 func WriteInvalidJsonResponse(w http.ResponseWriter, details string) {
 	WriteErrorResponse(w, http.StatusBadRequest, ERR_INVALID_JSON_BODY, ERR_INVALID_JSON_MESSAGE, details)
 }
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+	"github.com/meilisearch/meilisearch-go"
+)
+
+// itemFacetFields are the item fields facets can count, named like in the search index, where they are filterable.
+var itemFacetFields = []string{"type.name_id", "super_type.name_id", "level"}
+
+// numericFacetFields get stats and are ordered by value instead of count.
+var numericFacetFields = map[string]bool{"level": true}
+
+type APIFacetValue struct {
+	Value string `json:"value"`
+	Name  string `json:"name,omitempty" localized:"true"`
+	Count int    `json:"count"`
+}
+
+type APIFacetStats struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+type APIFacet struct {
+	Field  string          `json:"field"`
+	Values []APIFacetValue `json:"values"`
+	Stats  *APIFacetStats  `json:"stats,omitempty"`
+}
+
+// parseFacets parses a comma separated list of facet fields and keeps the order of the request.
+func parseFacets(facetsParam string) ([]string, error) {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(strings.ToLower(facetsParam), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+
+		allowed := false
+		for _, facetField := range itemFacetFields {
+			if facetField == field {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("unknown facet %s, use %s", field, strings.Join(itemFacetFields, ", "))
+		}
+
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("facets needs at least one field")
+	}
+
+	return fields, nil
+}
+
+// requestFacets parses the facets parameter. It writes the error response itself and returns false if the parameter
+// cannot be used. Without facets the fields are nil.
+func requestFacets(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	if !r.URL.Query().Has("facets") {
+		return nil, true
+	}
+
+	if isStreamed(r) {
+		e.WriteInvalidQueryResponse(w, "facets cannot be used when listing all entries.")
+		return nil, false
+	}
+
+	if format, _ := r.Context().Value("format").(string); format == utils.FormatCsv || format == utils.FormatNdjson {
+		e.WriteInvalidQueryResponse(w, "facets needs JSON or MessagePack responses.")
+		return nil, false
+	}
+
+	fields, err := parseFacets(r.URL.Query().Get("facets"))
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "facets is invalid: "+err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// facetCounts holds the values of the requested facets with their counts and the range of the numeric ones. Search
+// responses and in-memory listings fill it the same way, so both render the same facets. A nil facetCounts counts
+// nothing.
+type facetCounts struct {
+	fields       []string
+	distribution map[string]map[string]int
+	stats        map[string]*APIFacetStats
+	names        map[string]map[string]map[string]string // field, value, language
+}
+
+func newFacetCounts(fields []string) *facetCounts {
+	if fields == nil {
+		return nil
+	}
+
+	counts := &facetCounts{
+		fields:       fields,
+		distribution: make(map[string]map[string]int, len(fields)),
+		stats:        make(map[string]*APIFacetStats),
+	}
+	for _, field := range fields {
+		counts.distribution[field] = make(map[string]int)
+	}
+	return counts
+}
+
+func (counts *facetCounts) add(field string, value string) {
+	if _, ok := counts.distribution[field]; !ok {
+		return
+	}
+	counts.distribution[field][value]++
+
+	if numericFacetFields[field] {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return
+		}
+		stats, ok := counts.stats[field]
+		if !ok {
+			counts.stats[field] = &APIFacetStats{Min: number, Max: number}
+			return
+		}
+		stats.Min = min(stats.Min, number)
+		stats.Max = max(stats.Max, number)
+	}
+}
+
+// addItem counts an item with the same values the search index has for it.
+func (counts *facetCounts) addItem(item *mapping.MappedMultilangItemUnity) {
+	if counts == nil {
+		return
+	}
+	counts.add("type.name_id", itemTypeSlug(item.Type.Name["en"]))
+	counts.add("super_type.name_id", utils.CategoryIdMapping(item.Type.CategoryId))
+	counts.add("level", strconv.Itoa(item.Level))
+}
+
+// searchFacets returns the facets to request from the search index.
+func (counts *facetCounts) searchFacets() []string {
+	if counts == nil {
+		return nil
+	}
+	return counts.fields
+}
+
+// addSearchResponse takes the facet distribution and stats of a search response.
+func (counts *facetCounts) addSearchResponse(searchResp *meilisearch.SearchResponse) error {
+	if counts == nil {
+		return nil
+	}
+
+	if len(searchResp.FacetDistribution) != 0 {
+		var distribution map[string]map[string]int
+		if err := json.Unmarshal(searchResp.FacetDistribution, &distribution); err != nil {
+			return err
+		}
+		for field, values := range distribution {
+			if _, ok := counts.distribution[field]; !ok {
+				continue
+			}
+			for value, count := range values {
+				counts.distribution[field][value] += count
+			}
+		}
+	}
+
+	if len(searchResp.FacetStats) != 0 {
+		var stats map[string]struct {
+			Min float64 `json:"min"`
+			Max float64 `json:"max"`
+		}
+		if err := json.Unmarshal(searchResp.FacetStats, &stats); err != nil {
+			return err
+		}
+		for field, fieldStats := range stats {
+			if !numericFacetFields[field] {
+				continue
+			}
+			counts.stats[field] = &APIFacetStats{Min: int(fieldStats.Min), Max: int(fieldStats.Max)}
+		}
+	}
+
+	return nil
+}
+
+// loadNames reads the translated names of the item types and categories the facets count.
+func (counts *facetCounts) loadNames(txn *memdb.Txn) error {
+	if counts == nil {
+		return nil
+	}
+
+	itemTypes, err := itemTypeEntries(txn)
+	if err != nil {
+		return err
+	}
+
+	counts.names = map[string]map[string]map[string]string{
+		"type.name_id":       make(map[string]map[string]string),
+		"super_type.name_id": make(map[string]map[string]string),
+	}
+	for _, itemType := range itemTypes {
+		counts.names["type.name_id"][itemType.Slug] = itemType.Names
+	}
+	for categoryId, names := range itemCategoryNames {
+		counts.names["super_type.name_id"][utils.CategoryIdMapping(categoryId)] = names
+	}
+	return nil
+}
+
+// render returns the facets in the order they were requested. Values are ordered by count, numeric ones by value.
+func (counts *facetCounts) render(lang string) any {
+	facets := make([]APIFacet, len(counts.fields))
+	for i, field := range counts.fields {
+		values := make([]APIFacetValue, 0, len(counts.distribution[field]))
+		for value, count := range counts.distribution[field] {
+			values = append(values, APIFacetValue{
+				Value: value,
+				Name:  counts.names[field][value][lang],
+				Count: count,
+			})
+		}
+
+		if numericFacetFields[field] {
+			sort.Slice(values, func(i, j int) bool {
+				a, _ := strconv.Atoi(values[i].Value)
+				b, _ := strconv.Atoi(values[j].Value)
+				return a < b
+			})
+		} else {
+			sort.Slice(values, func(i, j int) bool {
+				if values[i].Count != values[j].Count {
+					return values[i].Count > values[j].Count
+				}
+				return values[i].Value < values[j].Value
+			})
+		}
+
+		facets[i] = APIFacet{
+			Field:  field,
+			Values: values,
+			Stats:  counts.stats[field],
+		}
+	}
+	return facets
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/meilisearch/meilisearch-go"
+)
+
+func TestParseFacets(t *testing.T) {
+	fields, err := parseFacets("level, Type.name_id,level")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fields, []string{"level", "type.name_id"}) {
+		t.Error("Expected level and type.name_id in request order, got ", fields)
+	}
+
+	for _, param := range []string{"", ",", "name", "level,effects"} {
+		if _, err := parseFacets(param); err == nil {
+			t.Error("Expected error for ", param)
+		}
+	}
+}
+
+func TestFacetCountsSearchMatchesListing(t *testing.T) {
+	fields := []string{"level", "type.name_id"}
+
+	listed := newFacetCounts(fields)
+	for _, item := range []*mapping.MappedMultilangItemUnity{
+		{AnkamaId: 1, Level: 30, Type: mapping.MappedMultilangItemTypeUnity{Name: map[string]string{"en": "Hat"}}},
+		{AnkamaId: 2, Level: 10, Type: mapping.MappedMultilangItemTypeUnity{Name: map[string]string{"en": "Hat"}}},
+		{AnkamaId: 3, Level: 30, Type: mapping.MappedMultilangItemTypeUnity{Name: map[string]string{"en": "Cape"}}},
+	} {
+		listed.addItem(item)
+	}
+
+	searched := newFacetCounts(fields)
+	err := searched.addSearchResponse(&meilisearch.SearchResponse{
+		FacetDistribution: json.RawMessage(`{"level": {"10": 1, "30": 2}, "type.name_id": {"cape": 1, "hat": 2}}`),
+		FacetStats:        json.RawMessage(`{"level": {"min": 10, "max": 30}}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listedFacets := listed.render("en").([]APIFacet)
+	if !reflect.DeepEqual(listedFacets, searched.render("en")) {
+		t.Error("Expected the same facets from search and listing, got ", listedFacets, " and ", searched.render("en"))
+	}
+
+	if listedFacets[0].Values[0].Value != "10" || listedFacets[0].Stats.Max != 30 || listedFacets[1].Values[0].Value != "hat" {
+		t.Error("Expected levels by value and types by count, got ", listedFacets)
+	}
+
+	var none *facetCounts
+	none.addItem(&mapping.MappedMultilangItemUnity{})
+	if none.searchFacets() != nil || wrapResponse(nil, "data", nil, none) != "data" {
+		t.Error("Expected nil facet counts to change nothing")
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+)
+
+var effectFilterRe = regexp.MustCompile(`^filter\[effects\.([0-9]+)\]\[(min|max|exists)\]$`)
+
+// EffectFilter restricts listings to entities carrying an effect with the given element id.
+// Min and Max are compared against the value range the effect can roll.
+type EffectFilter struct {
+	ElementId int
+	Min       *int
+	Max       *int
+	Exists    *bool
+}
+
+func parseEffectFilters(query url.Values) ([]EffectFilter, error) {
+	filters := make(map[int]*EffectFilter)
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[effects.") {
+			continue
+		}
+
+		groups := effectFilterRe.FindStringSubmatch(key)
+		if groups == nil {
+			return nil, fmt.Errorf("%s is not a valid effect filter, use filter[effects.<elementId>][min|max|exists]", key)
+		}
+
+		elementId, err := strconv.Atoi(groups[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s has an invalid element id", key)
+		}
+
+		filter, ok := filters[elementId]
+		if !ok {
+			filter = &EffectFilter{ElementId: elementId}
+			filters[elementId] = filter
+		}
+
+		value := strings.ToLower(values[0])
+		switch groups[2] {
+		case "min":
+			minValue, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a number", key)
+			}
+			filter.Min = &minValue
+		case "max":
+			maxValue, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a number", key)
+			}
+			filter.Max = &maxValue
+		case "exists":
+			exists, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a boolean", key)
+			}
+			filter.Exists = &exists
+		}
+	}
+
+	res := make([]EffectFilter, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Exists != nil && !*filter.Exists && (filter.Min != nil || filter.Max != nil) {
+			return nil, fmt.Errorf("filter[effects.%d][exists]=false cannot be combined with min or max", filter.ElementId)
+		}
+		res = append(res, *filter)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ElementId < res[j].ElementId
+	})
+
+	return res, nil
+}
+
+func elementExists(elementId int, txn *memdb.Txn) (bool, error) {
+	raw, err := txn.First("effect-condition-elements", "id", elementId)
+	if err != nil {
+		return false, err
+	}
+	return raw != nil, nil
+}
+
+func validateEffectFilters(filters []EffectFilter, txn *memdb.Txn) error {
+	for _, filter := range filters {
+		exists, err := elementExists(filter.ElementId, txn)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("unknown element id in filter[effects.%d]", filter.ElementId)
+		}
+	}
+	return nil
+}
+
+// effectBounds returns the lowest and highest value an effect can roll.
+// Effects without a meaningful value (meta effects or ignored min and max) are not numeric.
+func effectBounds(effect *mapping.MappedMultilangEffect) (int, int, bool) {
+	if effect.IsMeta || effect.MinMaxIrrelevant == -2 {
+		return 0, 0, false
+	}
+
+	if effect.MinMaxIrrelevant <= -1 || effect.Max < effect.Min {
+		return effect.Min, effect.Min, true
+	}
+
+	return effect.Min, effect.Max, true
+}
+
+func matchesEffectFilter(effects []mapping.MappedMultilangEffect, filter *EffectFilter) bool {
+	found := false
+	for i := range effects {
+		effect := &effects[i]
+		if effect.ElementId != filter.ElementId {
+			continue
+		}
+
+		if filter.Min == nil && filter.Max == nil {
+			found = true
+			break
+		}
+
+		low, high, numeric := effectBounds(effect)
+		if !numeric {
+			continue
+		}
+
+		if filter.Min != nil && high < *filter.Min {
+			continue
+		}
+
+		if filter.Max != nil && low > *filter.Max {
+			continue
+		}
+
+		found = true
+		break
+	}
+
+	if filter.Exists != nil && !*filter.Exists {
+		return !found
+	}
+
+	return found
+}
+
+func matchesEffectFilters(effects []mapping.MappedMultilangEffect, filters []EffectFilter) bool {
+	for i := range filters {
+		if !matchesEffectFilter(effects, &filters[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// setEffects flattens the bonuses of all item combinations of a set.
+func setEffects(set *mapping.MappedMultilangSetUnity) []mapping.MappedMultilangEffect {
+	var effects []mapping.MappedMultilangEffect
+	for _, combinationEffects := range set.Effects {
+		effects = append(effects, combinationEffects...)
+	}
+	return effects
+}
+
+// parseIdsFilter parses a comma separated list of ankama ids and keeps the order of the request.
+func parseIdsFilter(idsParam string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, rawId := range strings.Split(idsParam, ",") {
+		rawId = strings.TrimSpace(rawId)
+		if rawId == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rawId)
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("%s is not a valid id", rawId)
+		}
+
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("filter[ids] needs at least one id")
+	}
+
+	if len(ids) > maxBatchSize {
+		return nil, fmt.Errorf("filter[ids] accepts at most %d ids", maxBatchSize)
+	}
+
+	return ids, nil
+}
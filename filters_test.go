@@ -0,0 +1,184 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	mapping "github.com/dofusdude/dodumap"
+)
+
+func TestParseEffectFilters(t *testing.T) {
+	query, _ := url.ParseQuery("filter[effects.12][min]=80&filter[effects.3][exists]=true&filter[min_level]=150")
+
+	filters, err := parseEffectFilters(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(filters) != 2 {
+		t.Fatal("Expected 2 filters, got ", len(filters))
+	}
+
+	if filters[0].ElementId != 3 || filters[0].Exists == nil || !*filters[0].Exists {
+		t.Error("Expected exists filter on element 3, got ", filters[0])
+	}
+
+	if filters[1].ElementId != 12 || filters[1].Min == nil || *filters[1].Min != 80 {
+		t.Error("Expected min filter 80 on element 12, got ", filters[1])
+	}
+}
+
+func TestParseEffectFiltersInvalid(t *testing.T) {
+	invalid := []string{
+		"filter[effects.wisdom][min]=80",
+		"filter[effects.12][avg]=80",
+		"filter[effects.12][min]=many",
+		"filter[effects.12][exists]=maybe",
+		"filter[effects.12][exists]=false&filter[effects.12][min]=1",
+	}
+
+	for _, rawQuery := range invalid {
+		query, _ := url.ParseQuery(rawQuery)
+		if _, err := parseEffectFilters(query); err == nil {
+			t.Error("Expected error for ", rawQuery)
+		}
+	}
+}
+
+func TestMatchesEffectFilters(t *testing.T) {
+	effects := []mapping.MappedMultilangEffect{
+		{ElementId: 12, Min: 61, Max: 80},
+		{ElementId: 1, Min: 1, Max: 0},
+	}
+
+	minValue := 80
+	maxValue := 60
+	tooHigh := 81
+	yes := true
+	no := false
+
+	cases := []struct {
+		filters  []EffectFilter
+		expected bool
+	}{
+		{[]EffectFilter{{ElementId: 12, Min: &minValue}}, true},
+		{[]EffectFilter{{ElementId: 12, Min: &tooHigh}}, false},
+		{[]EffectFilter{{ElementId: 12, Max: &maxValue}}, false},
+		{[]EffectFilter{{ElementId: 1, Exists: &yes}}, true},
+		{[]EffectFilter{{ElementId: 1, Max: &maxValue}}, true},
+		{[]EffectFilter{{ElementId: 2, Exists: &yes}}, false},
+		{[]EffectFilter{{ElementId: 2, Exists: &no}}, true},
+		{[]EffectFilter{{ElementId: 12, Min: &minValue}, {ElementId: 1, Exists: &yes}}, true},
+	}
+
+	for _, c := range cases {
+		if matchesEffectFilters(effects, c.filters) != c.expected {
+			t.Error("Expected ", c.expected, " for ", c.filters)
+		}
+	}
+}
+
+func TestParseIdsFilter(t *testing.T) {
+	ids, err := parseIdsFilter("12, 3,12,7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []int{12, 3, 7}
+	if len(ids) != len(expected) {
+		t.Fatal("Expected ", expected, ", got ", ids)
+	}
+
+	for i := range ids {
+		if ids[i] != expected[i] {
+			t.Error("Expected ", expected[i], " at ", i, ", got ", ids[i])
+		}
+	}
+
+	if _, err = parseIdsFilter("1,two"); err == nil {
+		t.Error("Expected error for a non numeric id")
+	}
+
+	if _, err = parseIdsFilter(","); err == nil {
+		t.Error("Expected error for an empty id list")
+	}
+}
+
+func TestWeaponFilter(t *testing.T) {
+	bow := &mapping.MappedMultilangItemUnity{Type: mapping.MappedMultilangItemTypeUnity{ItemTypeId: 2}, ApCost: 5, MinRange: 2, Range: 6}
+	dagger := &mapping.MappedMultilangItemUnity{Type: mapping.MappedMultilangItemTypeUnity{ItemTypeId: 5}, ApCost: 3, MinRange: 1, Range: 1}
+
+	cases := []struct {
+		rawQuery string
+		bow      bool
+		dagger   bool
+	}{
+		{"", true, true},
+		{"filter[two_handed]=true", true, false},
+		{"filter[two_handed]=false", false, true},
+		{"filter[ap_cost]=3", false, true},
+		{"filter[min_range]=2", true, false},
+		{"filter[max_range]=5", false, true},
+		{"filter[min_range]=1&filter[max_range]=6", true, true},
+	}
+
+	for _, c := range cases {
+		query, _ := url.ParseQuery(c.rawQuery)
+		filter, err := parseWeaponFilter(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filter.matches(bow) != c.bow || filter.matches(dagger) != c.dagger {
+			t.Error("Expected bow ", c.bow, " and dagger ", c.dagger, " for ", c.rawQuery)
+		}
+	}
+
+	query, _ := url.ParseQuery("filter[ap_cost]=3&filter[min_range]=1&filter[max_range]=6&filter[two_handed]=true")
+	filter, err := parseWeaponFilter(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meiliFilter := filter.meiliFilter(); meiliFilter != "weapon.ap_cost = 3 AND weapon.min_range >= 1 AND weapon.range <= 6" {
+		t.Error("Expected the weapon stat filters for the search index, got ", meiliFilter)
+	}
+
+	for _, rawQuery := range []string{"filter[ap_cost]=-1", "filter[two_handed]=maybe", "filter[min_range]=4&filter[max_range]=2"} {
+		query, _ := url.ParseQuery(rawQuery)
+		if _, err := parseWeaponFilter(query); err == nil {
+			t.Error("Expected error for ", rawQuery)
+		}
+	}
+}
+
+func TestTwoHandedWeaponTypes(t *testing.T) {
+	weaponTypes := []struct {
+		id        int
+		name      string
+		twoHanded bool
+	}{
+		{2, "Bow", true},
+		{3, "Wand", false},
+		{4, "Staff", true},
+		{5, "Dagger", false},
+		{6, "Sword", false},
+		{7, "Hammer", true},
+		{8, "Shovel", true},
+		{19, "Axe", true},
+		{21, "Pickaxe", true},
+		{22, "Scythe", true},
+	}
+
+	twoHanded := 0
+	for _, weaponType := range weaponTypes {
+		if twoHandedWeaponTypeIds[weaponType.id] != weaponType.twoHanded {
+			t.Error("Expected ", weaponType.name, " (", weaponType.id, ") to be two-handed=", weaponType.twoHanded)
+		}
+		if weaponType.twoHanded {
+			twoHanded++
+		}
+	}
+
+	if len(twoHandedWeaponTypeIds) != twoHanded {
+		t.Error("Expected ", twoHanded, " two-handed weapon types, got ", len(twoHandedWeaponTypeIds))
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dofusdude/doduapi/utils"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	cases := map[string]string{
+		"": utils.FormatJson,
+		"text/html,application/xml;q=0.9,*/*;q=0.8": utils.FormatJson,
+		"text/csv": utils.FormatCsv,
+		"application/json;q=0.5, application/msgpack": utils.FormatMsgpack,
+		"application/x-ndjson":                        utils.FormatNdjson,
+	}
+
+	for accept, expected := range cases {
+		r := httptest.NewRequest("GET", "/en/items/equipment/all", nil)
+		r.Header.Set("Accept", accept)
+		format, err := utils.NegotiateFormat(r)
+		if err != nil || format != expected {
+			t.Error("Expected ", expected, " for ", accept, ", got ", format, err)
+		}
+	}
+
+	r := httptest.NewRequest("GET", "/en/items/equipment/all?format=csv", nil)
+	r.Header.Set("Accept", "application/json")
+	if format, _ := utils.NegotiateFormat(r); format != utils.FormatCsv {
+		t.Error("Expected format parameter to win over Accept, got ", format)
+	}
+
+	r = httptest.NewRequest("GET", "/en/items/equipment/all", nil)
+	r.Header.Set("Accept", "application/xml")
+	if _, err := utils.NegotiateFormat(r); err == nil {
+		t.Error("Expected an error for an unsupported Accept header")
+	}
+
+	r = httptest.NewRequest("GET", "/en/items/equipment/all?format=xml", nil)
+	if _, err := utils.NegotiateFormat(r); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestWriteDataCsvFlattensEffects(t *testing.T) {
+	page := APIPageItem{
+		Items: []APIListItem{
+			{
+				Id:   1,
+				Name: "Hat",
+				Effects: []ApiEffect{
+					{MinInt: 10, MaxInt: 20, Type: ApiEffectType{Name: "Vitality"}},
+				},
+			},
+			{
+				Id:   2,
+				Name: "Cape, blue",
+				Effects: []ApiEffect{
+					{MinInt: 5, MaxInt: 5, IgnoreMaxInt: true, Type: ApiEffectType{Name: "Wisdom"}},
+					{MinInt: 1, MaxInt: 3, Type: ApiEffectType{Name: "Vitality"}},
+				},
+			},
+		},
+	}
+
+	r := httptest.NewRequest("GET", "/en/items/equipment/all", nil)
+	r = r.WithContext(context.WithValue(r.Context(), "format", utils.FormatCsv))
+	w := httptest.NewRecorder()
+	if err := utils.WriteData(w, r, page); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatal("Expected a header and 2 rows, got ", len(lines))
+	}
+
+	header := strings.Split(lines[0], ",")
+	column := func(name string) int {
+		for i, h := range header {
+			if h == name {
+				return i
+			}
+		}
+		t.Fatal("Expected column ", name, " in ", lines[0])
+		return -1
+	}
+
+	second := strings.Split(strings.Replace(lines[2], `"Cape, blue"`, "Cape", 1), ",")
+	if second[column("effects.Vitality.max")] != "3" {
+		t.Error("Expected 3 in effects.Vitality.max, got ", second[column("effects.Vitality.max")])
+	}
+	if second[column("effects.Wisdom.max")] != "" {
+		t.Error("Expected an empty ignored max, got ", second[column("effects.Wisdom.max")])
+	}
+
+	if w.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Error("Expected text/csv, got ", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestStreamList(t *testing.T) {
+	items := []APIListItem{{Id: 1, Name: "Hat"}, {Id: 2, Name: "Cape"}, {Id: 3, Name: "Ring"}}
+	render := func(i int) any {
+		return items[i]
+	}
+
+	r := httptest.NewRequest("GET", "/en/items/equipment/all", nil)
+	w := httptest.NewRecorder()
+	if err := utils.StreamList(w, r, "items", len(items), render); err != nil {
+		t.Fatal(err)
+	}
+
+	var page APIPageItem
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatal("Expected valid JSON, got ", err, w.Body.String())
+	}
+	if len(page.Items) != 3 || page.Items[2].Name != "Ring" {
+		t.Error("Expected 3 items ending with Ring, got ", page.Items)
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), "format", utils.FormatNdjson))
+	w = httptest.NewRecorder()
+	if err := utils.StreamList(w, r, "items", len(items), render); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); len(lines) != 3 {
+		t.Error("Expected 3 NDJSON lines, got ", len(lines))
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	rendered := 0
+	err := utils.StreamList(httptest.NewRecorder(), r.WithContext(ctx), "items", len(items), func(i int) any {
+		rendered++
+		cancel() // the client disconnects after the first entry
+		return items[i]
+	})
+	if err == nil || rendered != 1 {
+		t.Error("Expected streaming to stop after the disconnect, rendered ", rendered, " entries")
+	}
+}
+
+func TestWriteStreamDeadline(t *testing.T) {
+	items := []APIListItem{{Id: 1, Name: "Hat"}, {Id: 2, Name: "Cape"}, {Id: 3, Name: "Ring"}}
+	slowRender := func(i int) any {
+		time.Sleep(5 * time.Millisecond)
+		return items[i]
+	}
+
+	// the request timeout does not apply to streams
+	handler := requestTimeout(time.Millisecond)(streamListing(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStream(w, r, "items", len(items), slowRender)
+	})))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/en/items/equipment/all", nil))
+
+	var page APIPageItem
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil || len(page.Items) != 3 || w.Code != http.StatusOK {
+		t.Error("Expected all 3 items with 200 after the request timeout, got ", w.Code, w.Body.String())
+	}
+
+	stream := func(ctx context.Context) (aborted bool) {
+		defer func() {
+			aborted = recover() == http.ErrAbortHandler
+		}()
+		r := httptest.NewRequest("GET", "/en/items/equipment/all", nil).WithContext(ctx)
+		writeStream(httptest.NewRecorder(), r, "items", len(items), slowRender)
+		return false
+	}
+
+	// a passed stream deadline must not look like a complete listing
+	deadlineCtx, cancelDeadline := context.WithCancelCause(context.Background())
+	cancelDeadline(context.DeadlineExceeded)
+	if !stream(deadlineCtx) {
+		t.Error("Expected the stream to be aborted after its deadline")
+	}
+
+	// a client that went away is not an error
+	disconnectCtx, disconnect := context.WithCancel(context.Background())
+	disconnect()
+	if stream(disconnectCtx) {
+		t.Error("Expected no abort after the client disconnected")
+	}
+}
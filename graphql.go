@@ -0,0 +1,884 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dofusdude/doduapi/almanax"
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/hashicorp/go-memdb"
+)
+
+const (
+	graphqlDefaultLimit = 20
+	graphqlMaxLimit     = 100
+)
+
+// graphqlState is shared by all resolvers of one request. The transaction pins the memdb generation, so a
+// query never mixes data from before and after an update.
+type graphqlState struct {
+	txn     *memdb.Txn
+	version string
+
+	almanaxOnce sync.Once
+	almanaxRepo *database.Repository
+}
+
+func newGraphqlState() *graphqlState {
+	return &graphqlState{
+		txn:     database.Db.Txn(false),
+		version: utils.CurrentRedBlueVersionStr(database.Version.MemDb),
+	}
+}
+
+func (s *graphqlState) table(name string) string {
+	return fmt.Sprintf("%s-%s", s.version, name)
+}
+
+// almanax opens the almanax repository only for queries that need it.
+func (s *graphqlState) almanax() *database.Repository {
+	s.almanaxOnce.Do(func() {
+		s.almanaxRepo = database.NewDatabaseRepository(context.Background(), config.DbDir)
+	})
+	return s.almanaxRepo
+}
+
+func (s *graphqlState) close() {
+	s.txn.Abort()
+	if s.almanaxRepo != nil {
+		s.almanaxRepo.Deinit()
+	}
+}
+
+func (s *graphqlState) item(ankamaId int) (*mapping.MappedMultilangItemUnity, error) {
+	raw, err := s.txn.First(s.table("all_items"), "id", ankamaId)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	return raw.(*mapping.MappedMultilangItemUnity), nil
+}
+
+func graphqlStateFrom(p graphql.ResolveParams) *graphqlState {
+	return p.Context.Value("graphql").(*graphqlState)
+}
+
+type graphqlSetBonus struct {
+	ItemCount int
+	Effects   []mapping.MappedMultilangEffect
+}
+
+type graphqlRecipeEntry struct {
+	ItemId   int
+	Quantity int
+}
+
+var (
+	graphqlSchemaMu    sync.Mutex
+	graphqlSchema      graphql.Schema
+	graphqlSchemaErr   error
+	graphqlSchemaLangs string // the languages of the Language enum in graphqlSchema
+
+	graphqlCategories = []string{"equipment", "consumables", "resources", "quest", "cosmetics"}
+)
+
+func langArg(p graphql.ResolveParams) string {
+	if lang, ok := p.Args["lang"].(string); ok && lang != "" {
+		return lang
+	}
+	return "en"
+}
+
+func intArg(p graphql.ResolveParams, name string, fallback int) int {
+	if value, ok := p.Args[name].(int); ok {
+		return value
+	}
+	return fallback
+}
+
+func limitArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: graphqlDefaultLimit, Description: fmt.Sprintf("At most %d.", graphqlMaxLimit)},
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+	}
+}
+
+func pageArgs(p graphql.ResolveParams) (int, int, error) {
+	limit := intArg(p, "limit", graphqlDefaultLimit)
+	offset := intArg(p, "offset", 0)
+	if limit < 1 || limit > graphqlMaxLimit {
+		return 0, 0, fmt.Errorf("limit must be between 1 and %d", graphqlMaxLimit)
+	}
+	if offset < 0 {
+		return 0, 0, fmt.Errorf("offset must not be negative")
+	}
+	return limit, offset, nil
+}
+
+func newGraphqlSchema() (graphql.Schema, error) {
+	languageValues := graphql.EnumValueConfigMap{}
+	for _, lang := range config.Languages() {
+		languageValues[lang] = &graphql.EnumValueConfig{Value: lang}
+	}
+	languageEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:   "Language",
+		Values: languageValues,
+	})
+
+	categoryValues := graphql.EnumValueConfigMap{}
+	for _, category := range graphqlCategories {
+		categoryValues[category] = &graphql.EnumValueConfig{Value: category}
+	}
+	categoryEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:   "ItemCategory",
+		Values: categoryValues,
+	})
+
+	langArgs := func() graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{
+			"lang": &graphql.ArgumentConfig{Type: languageEnum, DefaultValue: "en"},
+		}
+	}
+
+	translated := func(get func(source any) map[string]string) *graphql.Field {
+		return &graphql.Field{
+			Type: graphql.String,
+			Args: langArgs(),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return get(p.Source)[langArg(p)], nil
+			},
+		}
+	}
+
+	imageUrlsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ImageUrls",
+		Fields: graphql.Fields{
+			"icon": &graphql.Field{Type: graphql.String},
+			"sd":   &graphql.Field{Type: graphql.String},
+			"hq":   &graphql.Field{Type: graphql.String},
+			"hd":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	effectTypeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "EffectType",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(mapping.MappedMultilangEffect).ElementId, nil
+			}},
+			"name": translated(func(source any) map[string]string {
+				return source.(mapping.MappedMultilangEffect).Type
+			}),
+			"is_meta": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(mapping.MappedMultilangEffect).IsMeta, nil
+			}},
+			"is_active": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(mapping.MappedMultilangEffect).Active, nil
+			}},
+		},
+	})
+
+	effectType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Effect",
+		Fields: graphql.Fields{
+			"int_minimum": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(mapping.MappedMultilangEffect).Min, nil
+			}},
+			"int_maximum": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(mapping.MappedMultilangEffect).Max, nil
+			}},
+			"ignore_int_min": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (any, error) {
+				effect := p.Source.(mapping.MappedMultilangEffect)
+				return effect.IsMeta || effect.MinMaxIrrelevant == -2, nil
+			}},
+			"ignore_int_max": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (any, error) {
+				effect := p.Source.(mapping.MappedMultilangEffect)
+				return effect.IsMeta || effect.MinMaxIrrelevant <= -1, nil
+			}},
+			"type": &graphql.Field{Type: effectTypeType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source, nil
+			}},
+			"formatted": translated(func(source any) map[string]string {
+				return source.(mapping.MappedMultilangEffect).Templated
+			}),
+		},
+	})
+
+	conditionElementType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ConditionElement",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.Int},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	conditionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Condition",
+		Fields: graphql.Fields{
+			"operator":  &graphql.Field{Type: graphql.String},
+			"int_value": &graphql.Field{Type: graphql.Int},
+			"element":   &graphql.Field{Type: conditionElementType},
+		},
+	})
+
+	var conditionNodeType *graphql.Object
+	conditionNodeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "ConditionNode",
+		Fields: (graphql.FieldsThunk)(func() graphql.Fields {
+			return graphql.Fields{
+				"condition":  &graphql.Field{Type: conditionType},
+				"is_operand": &graphql.Field{Type: graphql.Boolean},
+				"relation":   &graphql.Field{Type: graphql.String},
+				"children":   &graphql.Field{Type: graphql.NewList(conditionNodeType)},
+			}
+		}),
+	})
+
+	rangeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Range",
+		Fields: graphql.Fields{
+			"min": &graphql.Field{Type: graphql.Int},
+			"max": &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	itemTypeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ItemType",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*mapping.MappedMultilangItemUnity).Type.ItemTypeId, nil
+			}},
+			"name": translated(func(source any) map[string]string {
+				return source.(*mapping.MappedMultilangItemUnity).Type.Name
+			}),
+		},
+	})
+
+	weaponOnly := func(get func(item *mapping.MappedMultilangItemUnity) any) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (any, error) {
+			item := p.Source.(*mapping.MappedMultilangItemUnity)
+			if item.Type.SuperTypeId != 2 {
+				return nil, nil
+			}
+			return get(item), nil
+		}
+	}
+
+	var itemType, setType, recipeType *graphql.Object
+
+	recipeEntryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "RecipeEntry",
+		Fields: (graphql.FieldsThunk)(func() graphql.Fields {
+			return graphql.Fields{
+				"quantity": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(graphqlRecipeEntry).Quantity, nil
+				}},
+				"item": &graphql.Field{Type: itemType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return graphqlStateFrom(p).item(p.Source.(graphqlRecipeEntry).ItemId)
+				}},
+			}
+		}),
+	})
+
+	recipeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Recipe",
+		Fields: (graphql.FieldsThunk)(func() graphql.Fields {
+			return graphql.Fields{
+				"level": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(mapping.MappedMultilangRecipe).Level, nil
+				}},
+				"result": &graphql.Field{Type: itemType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return graphqlStateFrom(p).item(p.Source.(mapping.MappedMultilangRecipe).ResultId)
+				}},
+				"entries": &graphql.Field{Type: graphql.NewList(recipeEntryType), Resolve: func(p graphql.ResolveParams) (any, error) {
+					recipe := p.Source.(mapping.MappedMultilangRecipe)
+					entries := make([]graphqlRecipeEntry, 0, len(recipe.Entries))
+					for _, entry := range recipe.Entries {
+						entries = append(entries, graphqlRecipeEntry{ItemId: entry.ItemId, Quantity: entry.Quantity})
+					}
+					return entries, nil
+				}},
+			}
+		}),
+	})
+
+	itemType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: (graphql.FieldsThunk)(func() graphql.Fields {
+			return graphql.Fields{
+				"ankama_id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangItemUnity).AnkamaId, nil
+				}},
+				"name": translated(func(source any) map[string]string {
+					return source.(*mapping.MappedMultilangItemUnity).Name
+				}),
+				"description": translated(func(source any) map[string]string {
+					return source.(*mapping.MappedMultilangItemUnity).Description
+				}),
+				"item_subtype": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return utils.CategoryIdApiMapping(p.Source.(*mapping.MappedMultilangItemUnity).Type.CategoryId), nil
+				}},
+				"type": &graphql.Field{Type: itemTypeType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source, nil
+				}},
+				"level": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangItemUnity).Level, nil
+				}},
+				"pods": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangItemUnity).Pods, nil
+				}},
+				"image_urls": &graphql.Field{Type: imageUrlsType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					item := p.Source.(*mapping.MappedMultilangItemUnity)
+					return RenderImageUrls(utils.ImageUrls(item.IconId, "item", config.ItemImgResolutions, config.ApiScheme, config.MajorVersion, config.ApiHostName, config.IsBeta)), nil
+				}},
+				"effects": &graphql.Field{Type: graphql.NewList(effectType), Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangItemUnity).Effects, nil
+				}},
+				"conditions": &graphql.Field{Type: conditionNodeType, Args: langArgs(), Resolve: func(p graphql.ResolveParams) (any, error) {
+					tree := RenderConditionTree(p.Source.(*mapping.MappedMultilangItemUnity).Conditions, langArg(p))
+					if tree == nil {
+						return nil, nil
+					}
+					return tree, nil
+				}},
+				"is_weapon": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangItemUnity).Type.SuperTypeId == 2, nil
+				}},
+				"critical_hit_probability": &graphql.Field{Type: graphql.Int, Resolve: weaponOnly(func(item *mapping.MappedMultilangItemUnity) any {
+					return item.CriticalHitProbability
+				})},
+				"critical_hit_bonus": &graphql.Field{Type: graphql.Int, Resolve: weaponOnly(func(item *mapping.MappedMultilangItemUnity) any {
+					return item.CriticalHitBonus
+				})},
+				"max_cast_per_turn": &graphql.Field{Type: graphql.Int, Resolve: weaponOnly(func(item *mapping.MappedMultilangItemUnity) any {
+					return item.MaxCastPerTurn
+				})},
+				"ap_cost": &graphql.Field{Type: graphql.Int, Resolve: weaponOnly(func(item *mapping.MappedMultilangItemUnity) any {
+					return item.ApCost
+				})},
+				"range": &graphql.Field{Type: rangeType, Resolve: weaponOnly(func(item *mapping.MappedMultilangItemUnity) any {
+					return APIRange{Min: item.MinRange, Max: item.Range}
+				})},
+				"recipe": &graphql.Field{Type: recipeType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					recipe, exists := GetRecipeIfExists(p.Source.(*mapping.MappedMultilangItemUnity).AnkamaId, graphqlStateFrom(p).txn)
+					if !exists {
+						return nil, nil
+					}
+					return recipe, nil
+				}},
+				"parent_set": &graphql.Field{Type: setType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					item := p.Source.(*mapping.MappedMultilangItemUnity)
+					if !item.HasParentSet {
+						return nil, nil
+					}
+					state := graphqlStateFrom(p)
+					raw, err := state.txn.First(state.table("sets"), "id", item.ParentSet.Id)
+					if err != nil || raw == nil {
+						return nil, err
+					}
+					return raw.(*mapping.MappedMultilangSetUnity), nil
+				}},
+			}
+		}),
+	})
+
+	setBonusType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SetBonus",
+		Fields: graphql.Fields{
+			"item_count": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(graphqlSetBonus).ItemCount, nil
+			}},
+			"effects": &graphql.Field{Type: graphql.NewList(effectType), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(graphqlSetBonus).Effects, nil
+			}},
+		},
+	})
+
+	setType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Set",
+		Fields: (graphql.FieldsThunk)(func() graphql.Fields {
+			return graphql.Fields{
+				"ankama_id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangSetUnity).AnkamaId, nil
+				}},
+				"name": translated(func(source any) map[string]string {
+					return source.(*mapping.MappedMultilangSetUnity).Name
+				}),
+				"level": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangSetUnity).Level, nil
+				}},
+				"contains_cosmetics": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangSetUnity).ContainsCosmetics, nil
+				}},
+				"contains_cosmetics_only": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*mapping.MappedMultilangSetUnity).ContainsCosmeticsOnly, nil
+				}},
+				"items": &graphql.Field{Type: graphql.NewList(itemType), Resolve: func(p graphql.ResolveParams) (any, error) {
+					state := graphqlStateFrom(p)
+					set := p.Source.(*mapping.MappedMultilangSetUnity)
+					items := make([]*mapping.MappedMultilangItemUnity, 0, len(set.ItemIds))
+					for _, itemId := range set.ItemIds {
+						item, err := state.item(itemId)
+						if err != nil {
+							return nil, err
+						}
+						if item != nil {
+							items = append(items, item)
+						}
+					}
+					return items, nil
+				}},
+				"bonuses": &graphql.Field{Type: graphql.NewList(setBonusType), Resolve: func(p graphql.ResolveParams) (any, error) {
+					set := p.Source.(*mapping.MappedMultilangSetUnity)
+					bonuses := make([]graphqlSetBonus, 0, len(set.Effects))
+					for itemCount, effects := range set.Effects {
+						bonuses = append(bonuses, graphqlSetBonus{ItemCount: itemCount, Effects: effects})
+					}
+					sort.Slice(bonuses, func(i, j int) bool {
+						return bonuses[i].ItemCount < bonuses[j].ItemCount
+					})
+					return bonuses, nil
+				}},
+			}
+		}),
+	})
+
+	mountFamilyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "MountFamily",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*mapping.MappedMultilangItemUnity).Type.ItemTypeId, nil
+			}},
+			"name": translated(func(source any) map[string]string {
+				return source.(*mapping.MappedMultilangItemUnity).Type.Name
+			}),
+		},
+	})
+
+	mountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mount",
+		Fields: graphql.Fields{
+			"ankama_id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*mapping.MappedMultilangItemUnity).AnkamaId, nil
+			}},
+			"name": translated(func(source any) map[string]string {
+				return source.(*mapping.MappedMultilangItemUnity).Name
+			}),
+			"family": &graphql.Field{Type: mountFamilyType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source, nil
+			}},
+			"image_urls": &graphql.Field{Type: imageUrlsType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				item := p.Source.(*mapping.MappedMultilangItemUnity)
+				return RenderImageUrls(utils.ImageUrls(item.IconId, "item", config.ItemImgResolutions, config.ApiScheme, config.MajorVersion, config.ApiHostName, config.IsBeta)), nil
+			}},
+			"effects": &graphql.Field{Type: graphql.NewList(effectType), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*mapping.MappedMultilangItemUnity).Effects, nil
+			}},
+		},
+	})
+
+	bonusTypeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AlmanaxBonusType",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(database.BonusType).NameID, nil
+			}},
+			"name": &graphql.Field{Type: graphql.String, Args: langArgs(), Resolve: func(p graphql.ResolveParams) (any, error) {
+				translated := almanax.BonusListingsToBonusIdTranslated([]database.BonusType{p.Source.(database.BonusType)}, langArg(p))
+				return translated[0].Name, nil
+			}},
+		},
+	})
+
+	// almanax days render through the REST renderer, so both APIs return the same texts
+	renderAlmanax := func(p graphql.ResolveParams, level *int) (almanax.AlmanaxResponse, error) {
+		return almanax.RenderAlmanaxResponse(p.Source.(*database.MappedAlmanax), langArg(p), level, graphqlStateFrom(p).txn)
+	}
+
+	almanaxBonusType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AlmanaxBonus",
+		Fields: graphql.Fields{
+			"description": &graphql.Field{Type: graphql.String, Args: langArgs(), Resolve: func(p graphql.ResolveParams) (any, error) {
+				response, err := renderAlmanax(p, nil)
+				return response.Bonus.Description, err
+			}},
+			"type": &graphql.Field{Type: bonusTypeType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*database.MappedAlmanax).BonusType, nil
+			}},
+		},
+	})
+
+	tributeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AlmanaxTribute",
+		Fields: graphql.Fields{
+			"quantity": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*database.MappedAlmanax).Tribute.Quantity, nil
+			}},
+			"item": &graphql.Field{Type: itemType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return graphqlStateFrom(p).item(int(p.Source.(*database.MappedAlmanax).Tribute.ItemAnkamaID))
+			}},
+		},
+	})
+
+	almanaxDayType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AlmanaxDay",
+		Fields: graphql.Fields{
+			"date": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*database.MappedAlmanax).Almanax.Date, nil
+			}},
+			"bonus": &graphql.Field{Type: almanaxBonusType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source, nil
+			}},
+			"reward_kamas": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return int(p.Source.(*database.MappedAlmanax).Almanax.RewardKamas), nil
+			}},
+			"reward_xp": &graphql.Field{
+				Type: graphql.Int,
+				Args: graphql.FieldConfigArgument{
+					"level": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					level := intArg(p, "level", 0)
+					if level < 1 || level > 200 {
+						return nil, fmt.Errorf("level must be between 1 and 200")
+					}
+					response, err := renderAlmanax(p, &level)
+					if err != nil {
+						return nil, err
+					}
+					return *response.RewardXp, nil
+				},
+			},
+			"tribute": &graphql.Field{Type: tributeType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source, nil
+			}},
+		},
+	})
+
+	idArgs := graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+	}
+
+	itemListArgs := limitArgs()
+	itemListArgs["category"] = &graphql.ArgumentConfig{Type: graphql.NewNonNull(categoryEnum)}
+	itemListArgs["min_level"] = &graphql.ArgumentConfig{Type: graphql.Int}
+	itemListArgs["max_level"] = &graphql.ArgumentConfig{Type: graphql.Int}
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"item": &graphql.Field{
+				Type:        itemType,
+				Description: "Any item by its ankama id, regardless of the category.",
+				Args:        idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return graphqlStateFrom(p).item(intArg(p, "id", 0))
+				},
+			},
+			"items": &graphql.Field{
+				Type: graphql.NewList(itemType),
+				Args: itemListArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					limit, offset, err := pageArgs(p)
+					if err != nil {
+						return nil, err
+					}
+
+					state := graphqlStateFrom(p)
+					category := p.Args["category"].(string)
+					if category == "quest" {
+						category = "quest_items"
+					}
+
+					it, err := state.txn.Get(state.table(category), "id")
+					if err != nil {
+						return nil, err
+					}
+
+					minLevel, hasMin := p.Args["min_level"].(int)
+					maxLevel, hasMax := p.Args["max_level"].(int)
+
+					items := make([]*mapping.MappedMultilangItemUnity, 0, limit)
+					skipped := 0
+					for obj := it.Next(); obj != nil && len(items) < limit; obj = it.Next() {
+						item := obj.(*mapping.MappedMultilangItemUnity)
+						if (hasMin && item.Level < minLevel) || (hasMax && item.Level > maxLevel) {
+							continue
+						}
+						if skipped < offset {
+							skipped++
+							continue
+						}
+						items = append(items, item)
+					}
+					return items, nil
+				},
+			},
+			"set": &graphql.Field{
+				Type: setType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					state := graphqlStateFrom(p)
+					raw, err := state.txn.First(state.table("sets"), "id", intArg(p, "id", 0))
+					if err != nil || raw == nil {
+						return nil, err
+					}
+					return raw.(*mapping.MappedMultilangSetUnity), nil
+				},
+			},
+			"sets": &graphql.Field{
+				Type: graphql.NewList(setType),
+				Args: limitArgs(),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					limit, offset, err := pageArgs(p)
+					if err != nil {
+						return nil, err
+					}
+
+					state := graphqlStateFrom(p)
+					it, err := state.txn.Get(state.table("sets"), "id")
+					if err != nil {
+						return nil, err
+					}
+
+					sets := make([]*mapping.MappedMultilangSetUnity, 0, limit)
+					skipped := 0
+					for obj := it.Next(); obj != nil && len(sets) < limit; obj = it.Next() {
+						if skipped < offset {
+							skipped++
+							continue
+						}
+						sets = append(sets, obj.(*mapping.MappedMultilangSetUnity))
+					}
+					return sets, nil
+				},
+			},
+			"mount": &graphql.Field{
+				Type: mountType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					state := graphqlStateFrom(p)
+					raw, err := state.txn.First(state.table("equipment"), "id", intArg(p, "id", 0))
+					if err != nil || raw == nil {
+						return nil, err
+					}
+					item := raw.(*mapping.MappedMultilangItemUnity)
+					if !mountEquipmentTypeIds[item.Type.ItemTypeId] {
+						return nil, nil
+					}
+					return item, nil
+				},
+			},
+			"mounts": &graphql.Field{
+				Type: graphql.NewList(mountType),
+				Args: limitArgs(),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					limit, offset, err := pageArgs(p)
+					if err != nil {
+						return nil, err
+					}
+
+					state := graphqlStateFrom(p)
+					it, err := state.txn.Get(state.table("equipment"), "id")
+					if err != nil {
+						return nil, err
+					}
+
+					mounts := make([]*mapping.MappedMultilangItemUnity, 0, limit)
+					skipped := 0
+					for obj := it.Next(); obj != nil && len(mounts) < limit; obj = it.Next() {
+						item := obj.(*mapping.MappedMultilangItemUnity)
+						if !mountEquipmentTypeIds[item.Type.ItemTypeId] {
+							continue
+						}
+						if skipped < offset {
+							skipped++
+							continue
+						}
+						mounts = append(mounts, item)
+					}
+					return mounts, nil
+				},
+			},
+			"recipe": &graphql.Field{
+				Type:        recipeType,
+				Description: "The recipe crafting the item with the given ankama id.",
+				Args: graphql.FieldConfigArgument{
+					"item_id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					recipe, exists := GetRecipeIfExists(intArg(p, "item_id", 0), graphqlStateFrom(p).txn)
+					if !exists {
+						return nil, nil
+					}
+					return recipe, nil
+				},
+			},
+			"almanax": &graphql.Field{
+				Type:        graphql.NewList(almanaxDayType),
+				Description: "Almanax days between from and to (inclusive), formatted as YYYY-MM-DD.",
+				Args: graphql.FieldConfigArgument{
+					"from":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"to":         &graphql.ArgumentConfig{Type: graphql.String},
+					"bonus_type": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					fromStr := p.Args["from"].(string)
+					toStr, ok := p.Args["to"].(string)
+					if !ok || toStr == "" {
+						toStr = fromStr
+					}
+
+					from, err := time.Parse("2006-01-02", fromStr)
+					if err != nil {
+						return nil, fmt.Errorf("invalid from date")
+					}
+					to, err := time.Parse("2006-01-02", toStr)
+					if err != nil {
+						return nil, fmt.Errorf("invalid to date")
+					}
+					if from.After(to) {
+						return nil, fmt.Errorf("from date is after to date")
+					}
+					if to.Sub(from).Hours() > float64(config.AlmanaxMaxLookAhead)*24 {
+						return nil, fmt.Errorf("date range is too large")
+					}
+
+					var mappedAlmanax []database.MappedAlmanax
+					if bonusType, ok := p.Args["bonus_type"].(string); ok && bonusType != "" {
+						mappedAlmanax, err = graphqlStateFrom(p).almanax().GetAlmanaxByDateRangeAndNameID(fromStr, toStr, bonusType)
+					} else {
+						mappedAlmanax, err = graphqlStateFrom(p).almanax().GetAlmanaxByDateRange(fromStr, toStr)
+					}
+					if err != nil {
+						return nil, err
+					}
+
+					days := make([]*database.MappedAlmanax, 0, len(mappedAlmanax))
+					for i := range mappedAlmanax {
+						days = append(days, &mappedAlmanax[i])
+					}
+					return days, nil
+				},
+			},
+			"almanax_bonus_types": &graphql.Field{
+				Type: graphql.NewList(bonusTypeType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return graphqlStateFrom(p).almanax().GetBonusTypes()
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+}
+
+type GraphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func writeGraphqlErrors(w http.ResponseWriter, status int, errs []gqlerrors.FormattedError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(graphql.Result{Errors: errs})
+}
+
+// currentGraphqlSchema returns the schema for the current languages. It is built again when an update changed them.
+func currentGraphqlSchema() (graphql.Schema, error) {
+	graphqlSchemaMu.Lock()
+	defer graphqlSchemaMu.Unlock()
+
+	langs := strings.Join(config.Languages(), ",")
+	if graphqlSchemaLangs != langs || graphqlSchemaErr != nil {
+		graphqlSchema, graphqlSchemaErr = newGraphqlSchema()
+		graphqlSchemaLangs = langs
+	}
+	return graphqlSchema, graphqlSchemaErr
+}
+
+func GraphqlHandler(w http.ResponseWriter, r *http.Request) {
+	schema, err := currentGraphqlSchema()
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not build GraphQL schema: "+err.Error())
+		return
+	}
+
+	var request GraphqlRequest
+	if r.Method == http.MethodGet {
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				e.WriteInvalidQueryResponse(w, "variables is not valid JSON: "+err.Error())
+				return
+			}
+		}
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			e.WriteInvalidJsonResponse(w, "Could not decode GraphQL request: "+err.Error())
+			return
+		}
+	}
+
+	if request.Query == "" {
+		e.WriteInvalidQueryResponse(w, "query must not be empty.")
+		return
+	}
+
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		writeGraphqlErrors(w, http.StatusBadRequest, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	validation := graphql.ValidateDocument(&schema, document, nil)
+	if !validation.IsValid {
+		writeGraphqlErrors(w, http.StatusBadRequest, validation.Errors)
+		return
+	}
+
+	if err = checkGraphqlLimits(&schema, document, request.OperationName, request.Variables); err != nil {
+		writeGraphqlErrors(w, http.StatusBadRequest, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	state := newGraphqlState()
+	defer state.close()
+
+	utils.RequestsTotal.Inc()
+	utils.RequestsGraphql.Inc()
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       context.WithValue(r.Context(), "graphql", state),
+	})
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		return
+	}
+}
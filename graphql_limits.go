@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// graphqlListCost is the assumed size of lists without a limit argument, like recipe entries or set bonuses.
+const graphqlListCost = 10
+
+type graphqlCostWalker struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// checkGraphqlLimits rejects queries that nest deeper than config.GraphqlMaxDepth or would resolve more fields
+// than config.GraphqlMaxComplexity before any resolver runs. Introspection fields count like any other field.
+func checkGraphqlLimits(schema *graphql.Schema, document *ast.Document, operationName string, variables map[string]any) error {
+	walker := graphqlCostWalker{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			walker.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		depth, complexity := walker.selectionSet(operation.SelectionSet, schema.QueryType())
+		if depth > config.GraphqlMaxDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", depth, config.GraphqlMaxDepth)
+		}
+		if complexity > config.GraphqlMaxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, config.GraphqlMaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+func (c *graphqlCostWalker) objectByName(name string, fallback *graphql.Object) *graphql.Object {
+	if object, ok := c.schema.Type(name).(*graphql.Object); ok {
+		return object
+	}
+	return fallback
+}
+
+func (c *graphqlCostWalker) selectionSet(selectionSet *ast.SelectionSet, parent *graphql.Object) (int, int) {
+	if selectionSet == nil || parent == nil {
+		return 0, 0
+	}
+
+	maxDepth := 0
+	complexity := 0
+	for _, selection := range selectionSet.Selections {
+		var depth, cost int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			depth, cost = c.field(selection, parent)
+		case *ast.InlineFragment:
+			fragmentParent := parent
+			if selection.TypeCondition != nil {
+				fragmentParent = c.objectByName(selection.TypeCondition.Name.Value, parent)
+			}
+			depth, cost = c.selectionSet(selection.SelectionSet, fragmentParent)
+		case *ast.FragmentSpread:
+			fragment, ok := c.fragments[selection.Name.Value]
+			if !ok {
+				continue
+			}
+			depth, cost = c.selectionSet(fragment.SelectionSet, c.objectByName(fragment.TypeCondition.Name.Value, parent))
+		}
+
+		maxDepth = max(maxDepth, depth)
+		complexity += cost
+	}
+
+	return maxDepth, complexity
+}
+
+// introspectionFields are the meta fields every type has, but that are not part of its field map.
+var introspectionFields = map[string]*graphql.FieldDefinition{
+	"__schema":   graphql.SchemaMetaFieldDef,
+	"__type":     graphql.TypeMetaFieldDef,
+	"__typename": graphql.TypeNameMetaFieldDef,
+}
+
+func (c *graphqlCostWalker) field(field *ast.Field, parent *graphql.Object) (int, int) {
+	definition, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		definition, ok = introspectionFields[field.Name.Value]
+	}
+	if !ok {
+		return 1, 1
+	}
+
+	child, _ := graphql.GetNamed(definition.Type).(*graphql.Object)
+	childDepth, childComplexity := c.selectionSet(field.SelectionSet, child)
+
+	if isGraphqlList(definition.Type) {
+		childComplexity *= c.listSize(field, definition)
+	}
+
+	return childDepth + 1, childComplexity + 1
+}
+
+func isGraphqlList(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
+
+// listSize returns the limit argument of a list field clamped to the allowed range, or the default cost for lists
+// without one. Limits the resolver would reject still count as the maximum so they can't lower the cost of siblings.
+func (c *graphqlCostWalker) listSize(field *ast.Field, definition *graphql.FieldDefinition) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if limit, err := strconv.Atoi(value.Value); err == nil {
+				return clampGraphqlLimit(limit)
+			}
+		case *ast.Variable:
+			switch limit := c.variables[value.Name.Value].(type) {
+			case float64:
+				return clampGraphqlLimit(int(limit))
+			case int:
+				return clampGraphqlLimit(limit)
+			}
+		}
+		return graphqlMaxLimit
+	}
+
+	for _, argument := range definition.Args {
+		if argument.Name() == "limit" {
+			if limit, ok := argument.DefaultValue.(int); ok {
+				return limit
+			}
+		}
+	}
+
+	return graphqlListCost
+}
+
+func clampGraphqlLimit(limit int) int {
+	if limit < 1 || limit > graphqlMaxLimit {
+		return graphqlMaxLimit
+	}
+	return limit
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestGraphqlLimits(t *testing.T) {
+	config.SetLanguages([]string{"de", "en", "es", "fr", "pt"})
+	defer config.SetLanguages(nil)
+
+	schema, err := newGraphqlSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.GraphqlMaxDepth = 6
+	config.GraphqlMaxComplexity = 500
+
+	cases := []struct {
+		query string
+		valid bool
+	}{
+		{`{ item(id: 1) { name(lang: fr) recipe { entries { quantity item { image_urls { icon } } } } } }`, true},
+		{`{ item(id: 1) { parent_set { items { recipe { entries { item { name } } } } } } }`, false},
+		{`{ items(category: equipment, limit: 100) { name effects { formatted } } }`, false},
+		{`query Q($limit: Int) { sets(limit: $limit) { name } }`, true},
+		{`{ items(category: equipment, limit: 100) { name effects { formatted } } sets(limit: -1000) { name items { name } } }`, false},
+		{`query Q($negative: Int) { items(category: equipment, limit: 100) { name effects { formatted } } sets(limit: $negative) { name items { name } } }`, false},
+		{`{ __schema { queryType { name } types { name } } }`, true},
+		{`{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`, false},
+		{`{ __type(name: "Item") { fields { type { fields { type { fields { name } } } } } } }`, false},
+	}
+
+	for _, c := range cases {
+		document, err := parser.Parse(parser.ParseParams{Source: c.query})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if validation := graphql.ValidateDocument(&schema, document, nil); !validation.IsValid {
+			t.Fatal("Expected valid query ", c.query, ", got ", validation.Errors)
+		}
+
+		err = checkGraphqlLimits(&schema, document, "", map[string]any{"limit": float64(5), "negative": float64(-1000)})
+		if (err == nil) != c.valid {
+			t.Error("Expected valid=", c.valid, " for ", c.query, ", got ", err)
+		}
+	}
+}
@@ -0,0 +1,624 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dofusdude/doduapi/almanax"
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	"github.com/dofusdude/doduapi/pb"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var grpcCategoryTables = map[pb.ItemCategory]string{
+	pb.ItemCategory_ITEM_CATEGORY_UNSPECIFIED: "all_items",
+	pb.ItemCategory_ITEM_CATEGORY_EQUIPMENT:   "equipment",
+	pb.ItemCategory_ITEM_CATEGORY_CONSUMABLES: "consumables",
+	pb.ItemCategory_ITEM_CATEGORY_RESOURCES:   "resources",
+	pb.ItemCategory_ITEM_CATEGORY_QUEST:       "quest_items",
+	pb.ItemCategory_ITEM_CATEGORY_COSMETICS:   "cosmetics",
+}
+
+// NewGrpcServer registers the encyclopedia and almanax services. Messages are built from the REST renderers, so
+// both APIs return the same data.
+func NewGrpcServer() *grpc.Server {
+	server := grpc.NewServer()
+	pb.RegisterEncyclopediaServer(server, &encyclopediaServer{})
+	pb.RegisterAlmanaxServer(server, &almanaxServer{})
+	return server
+}
+
+type encyclopediaServer struct {
+	pb.UnimplementedEncyclopediaServer
+}
+
+type almanaxServer struct {
+	pb.UnimplementedAlmanaxServer
+}
+
+func grpcLang(lang string) (string, error) {
+	if lang == "" {
+		return "en", nil
+	}
+	if !slices.Contains(config.Languages(), lang) {
+		return "", status.Errorf(codes.InvalidArgument, "unknown language %s", lang)
+	}
+	return lang, nil
+}
+
+func grpcTable(name string) string {
+	return fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), name)
+}
+
+func grpcRequest() {
+	utils.RequestsTotal.Inc()
+	utils.RequestsGrpc.Inc()
+}
+
+func toPbImageUrls(urls ApiImageUrls) *pb.ImageUrls {
+	return &pb.ImageUrls{
+		Icon: urls.Icon,
+		Sd:   urls.Sd,
+		Hq:   urls.Hq,
+		Hd:   urls.Hd,
+	}
+}
+
+func toPbEffects(effects []ApiEffect) []*pb.Effect {
+	if len(effects) == 0 {
+		return nil
+	}
+
+	res := make([]*pb.Effect, 0, len(effects))
+	for _, effect := range effects {
+		res = append(res, &pb.Effect{
+			IntMinimum: int32(effect.MinInt),
+			IntMaximum: int32(effect.MaxInt),
+			Type: &pb.EffectType{
+				Id:       int32(effect.Type.Id),
+				Name:     effect.Type.Name,
+				IsMeta:   effect.Type.IsMeta,
+				IsActive: effect.Type.IsActive,
+			},
+			IgnoreIntMin: effect.IgnoreMinInt,
+			IgnoreIntMax: effect.IgnoreMaxInt,
+			Formatted:    effect.Formatted,
+		})
+	}
+	return res
+}
+
+func toPbConditionNode(node *ApiConditionNode) *pb.ConditionNode {
+	if node == nil {
+		return nil
+	}
+
+	res := &pb.ConditionNode{
+		IsOperand: node.IsOperand,
+	}
+	if node.Condition != nil {
+		res.Condition = &pb.Condition{
+			Operator: node.Condition.Operator,
+			IntValue: int32(node.Condition.IntValue),
+			Element: &pb.ConditionElement{
+				Id:   int32(node.Condition.Element.Id),
+				Name: node.Condition.Element.Name,
+			},
+		}
+	}
+	if node.Relation != nil {
+		res.Relation = *node.Relation
+	}
+	for _, child := range node.Children {
+		res.Children = append(res.Children, toPbConditionNode(child))
+	}
+	return res
+}
+
+func toPbRecipeEntries(recipe []APIRecipe) []*pb.RecipeEntry {
+	if len(recipe) == 0 {
+		return nil
+	}
+
+	res := make([]*pb.RecipeEntry, 0, len(recipe))
+	for _, entry := range recipe {
+		res = append(res, &pb.RecipeEntry{
+			ItemAnkamaId: int32(entry.AnkamaId),
+			ItemSubtype:  entry.ItemType,
+			Quantity:     int32(entry.Quantity),
+		})
+	}
+	return res
+}
+
+func toPbSetReference(link *APISetReverseLink) *pb.SetReference {
+	if link == nil {
+		return nil
+	}
+	return &pb.SetReference{
+		Id:   int32(link.Id),
+		Name: link.Name,
+	}
+}
+
+func toPbItem(item *mapping.MappedMultilangItemUnity, lang string, txn *memdb.Txn) *pb.Item {
+	res := &pb.Item{
+		ItemSubtype: utils.CategoryIdApiMapping(item.Type.CategoryId),
+	}
+
+	switch rendered := RenderSingleItem(item, lang, txn).(type) {
+	case APIWeapon:
+		res.AnkamaId = int32(rendered.Id)
+		res.Name = rendered.Name
+		res.Description = rendered.Description
+		res.Type = &pb.ItemType{Id: int32(rendered.Type.Id), Name: rendered.Type.Name}
+		res.Level = int32(rendered.Level)
+		res.Pods = int32(rendered.Pods)
+		res.ImageUrls = toPbImageUrls(rendered.ImageUrls)
+		res.Effects = toPbEffects(rendered.Effects)
+		res.Conditions = toPbConditionNode(rendered.Conditions)
+		res.Recipe = toPbRecipeEntries(rendered.Recipe)
+		res.ParentSet = toPbSetReference(rendered.ParentSet)
+		res.Weapon = &pb.WeaponStats{
+			CriticalHitProbability: int32(rendered.CriticalHitProbability),
+			CriticalHitBonus:       int32(rendered.CriticalHitBonus),
+			MaxCastPerTurn:         int32(rendered.MaxCastPerTurn),
+			ApCost:                 int32(rendered.ApCost),
+			Range: &pb.Range{
+				Min: int32(rendered.Range.Min),
+				Max: int32(rendered.Range.Max),
+			},
+		}
+	case APIEquipment:
+		res.AnkamaId = int32(rendered.Id)
+		res.Name = rendered.Name
+		res.Description = rendered.Description
+		res.Type = &pb.ItemType{Id: int32(rendered.Type.Id), Name: rendered.Type.Name}
+		res.Level = int32(rendered.Level)
+		res.Pods = int32(rendered.Pods)
+		res.ImageUrls = toPbImageUrls(rendered.ImageUrls)
+		res.Effects = toPbEffects(rendered.Effects)
+		res.Conditions = toPbConditionNode(rendered.Conditions)
+		res.Recipe = toPbRecipeEntries(rendered.Recipe)
+		res.ParentSet = toPbSetReference(rendered.ParentSet)
+	case APIResource:
+		res.AnkamaId = int32(rendered.Id)
+		res.Name = rendered.Name
+		res.Description = rendered.Description
+		res.Type = &pb.ItemType{Id: int32(rendered.Type.Id), Name: rendered.Type.Name}
+		res.Level = int32(rendered.Level)
+		res.Pods = int32(rendered.Pods)
+		res.ImageUrls = toPbImageUrls(rendered.ImageUrls)
+		res.Effects = toPbEffects(rendered.Effects)
+		res.Conditions = toPbConditionNode(rendered.Conditions)
+		res.Recipe = toPbRecipeEntries(rendered.Recipe)
+	}
+
+	return res
+}
+
+func toPbSet(set *mapping.MappedMultilangSetUnity, lang string) *pb.Set {
+	rendered := RenderSet(set, lang)
+
+	res := &pb.Set{
+		AnkamaId:              int32(rendered.AnkamaId),
+		Name:                  rendered.Name,
+		HighestEquipmentLevel: int32(rendered.Level),
+		ContainsCosmetics:     rendered.ContainsCosmetics,
+		ContainsCosmeticsOnly: rendered.ContainsCosmeticsOnly,
+	}
+	for _, itemId := range rendered.ItemIds {
+		res.EquipmentIds = append(res.EquipmentIds, int32(itemId))
+	}
+
+	// maps have no order, so the bonuses are sorted by the amount of worn items
+	itemCounts := make([]int, 0, len(rendered.Effects))
+	for itemCount := range rendered.Effects {
+		itemCounts = append(itemCounts, itemCount)
+	}
+	slices.Sort(itemCounts)
+	for _, itemCount := range itemCounts {
+		res.Bonuses = append(res.Bonuses, &pb.SetBonus{
+			ItemCount: int32(itemCount),
+			Effects:   toPbEffects(rendered.Effects[itemCount]),
+		})
+	}
+
+	return res
+}
+
+func toPbMount(item *mapping.MappedMultilangItemUnity, lang string) *pb.Mount {
+	rendered := RenderEquipmentAsMount(item, lang)
+	return &pb.Mount{
+		AnkamaId: int32(rendered.Id),
+		Name:     rendered.Name,
+		Family: &pb.MountFamily{
+			Id:   int32(rendered.Family.Id),
+			Name: rendered.Family.Name,
+		},
+		ImageUrls: toPbImageUrls(rendered.ImageUrls),
+		Effects:   toPbEffects(rendered.Effects),
+	}
+}
+
+func toPbRecipe(recipe mapping.MappedMultilangRecipe) *pb.Recipe {
+	return &pb.Recipe{
+		ResultAnkamaId: int32(recipe.ResultId),
+		Level:          int32(recipe.Level),
+		Entries:        toPbRecipeEntries(RenderRecipe(recipe, database.Db)),
+	}
+}
+
+func (s *encyclopediaServer) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.Item, error) {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+
+	table, ok := grpcCategoryTables[req.GetCategory()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown category %d", req.GetCategory())
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(grpcTable(table), "id", int(req.GetAnkamaId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if raw == nil {
+		return nil, status.Errorf(codes.NotFound, "item %d not found", req.GetAnkamaId())
+	}
+
+	grpcRequest()
+	return toPbItem(raw.(*mapping.MappedMultilangItemUnity), lang, txn), nil
+}
+
+func (s *encyclopediaServer) ListItems(req *pb.ListItemsRequest, stream grpc.ServerStreamingServer[pb.Item]) error {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return err
+	}
+
+	table, ok := grpcCategoryTables[req.GetCategory()]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown category %d", req.GetCategory())
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(grpcTable(table), "id")
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	grpcRequest()
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(toPbItem(obj.(*mapping.MappedMultilangItemUnity), lang, txn)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *encyclopediaServer) GetSet(ctx context.Context, req *pb.GetByIdRequest) (*pb.Set, error) {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(grpcTable("sets"), "id", int(req.GetAnkamaId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if raw == nil {
+		return nil, status.Errorf(codes.NotFound, "set %d not found", req.GetAnkamaId())
+	}
+
+	grpcRequest()
+	return toPbSet(raw.(*mapping.MappedMultilangSetUnity), lang), nil
+}
+
+func (s *encyclopediaServer) ListSets(req *pb.ListRequest, stream grpc.ServerStreamingServer[pb.Set]) error {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return err
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(grpcTable("sets"), "id")
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	grpcRequest()
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(toPbSet(obj.(*mapping.MappedMultilangSetUnity), lang)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *encyclopediaServer) GetMount(ctx context.Context, req *pb.GetByIdRequest) (*pb.Mount, error) {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(grpcTable("equipment"), "id", int(req.GetAnkamaId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if raw == nil || !mountEquipmentTypeIds[raw.(*mapping.MappedMultilangItemUnity).Type.ItemTypeId] {
+		return nil, status.Errorf(codes.NotFound, "mount %d not found", req.GetAnkamaId())
+	}
+
+	grpcRequest()
+	return toPbMount(raw.(*mapping.MappedMultilangItemUnity), lang), nil
+}
+
+func (s *encyclopediaServer) ListMounts(req *pb.ListRequest, stream grpc.ServerStreamingServer[pb.Mount]) error {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return err
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(grpcTable("equipment"), "id")
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	grpcRequest()
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		item := obj.(*mapping.MappedMultilangItemUnity)
+		if !mountEquipmentTypeIds[item.Type.ItemTypeId] {
+			continue
+		}
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(toPbMount(item, lang)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *encyclopediaServer) GetRecipe(ctx context.Context, req *pb.GetByIdRequest) (*pb.Recipe, error) {
+	if _, err := grpcLang(req.GetLang()); err != nil {
+		return nil, err
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	recipe, exists := GetRecipeIfExists(int(req.GetAnkamaId()), txn)
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "no recipe crafts item %d", req.GetAnkamaId())
+	}
+
+	grpcRequest()
+	return toPbRecipe(recipe), nil
+}
+
+func (s *encyclopediaServer) ListRecipes(req *pb.ListRequest, stream grpc.ServerStreamingServer[pb.Recipe]) error {
+	if _, err := grpcLang(req.GetLang()); err != nil {
+		return err
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(grpcTable("recipes"), "id")
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	grpcRequest()
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(toPbRecipe(*obj.(*mapping.MappedMultilangRecipe))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func grpcLevel(level *int32) (*int, error) {
+	if level == nil {
+		return nil, nil
+	}
+	if *level < 1 || *level > 200 {
+		return nil, status.Error(codes.InvalidArgument, "level must be between 1 and 200")
+	}
+	levelInt := int(*level)
+	return &levelInt, nil
+}
+
+func toPbAlmanaxDay(m *database.MappedAlmanax, lang string, level *int, txn *memdb.Txn) (*pb.AlmanaxDay, error) {
+	response, err := almanax.RenderAlmanaxResponse(m, lang, level, txn)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	day := &pb.AlmanaxDay{
+		Date: response.Date,
+		Bonus: &pb.AlmanaxBonus{
+			Description: response.Bonus.Description,
+			Type: &pb.AlmanaxBonusType{
+				Id:   response.Bonus.BonusType.Id,
+				Name: response.Bonus.BonusType.Name,
+			},
+		},
+		RewardKamas: int32(response.RewardKamas),
+		Tribute: &pb.AlmanaxTribute{
+			Item: &pb.AlmanaxTributeItem{
+				AnkamaId: int32(response.Tribute.Item.AnkamaId),
+				Name:     response.Tribute.Item.Name,
+				Subtype:  response.Tribute.Item.Subtype,
+				ImageUrls: &pb.ImageUrls{
+					Icon: response.Tribute.Item.ImageUrls.Icon,
+					Sd:   response.Tribute.Item.ImageUrls.Sd,
+					Hq:   response.Tribute.Item.ImageUrls.Hq,
+					Hd:   response.Tribute.Item.ImageUrls.Hd,
+				},
+			},
+			Quantity: int32(response.Tribute.Quantity),
+		},
+	}
+	if response.RewardXp != nil {
+		rewardXp := int32(*response.RewardXp)
+		day.RewardXp = &rewardXp
+	}
+	return day, nil
+}
+
+func (s *almanaxServer) GetAlmanax(ctx context.Context, req *pb.GetAlmanaxRequest) (*pb.AlmanaxDay, error) {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := grpcLevel(req.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse("2006-01-02", req.GetDate()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "date must be formatted as YYYY-MM-DD")
+	}
+
+	almDb := database.NewDatabaseRepository(context.Background(), config.DbDir)
+	defer almDb.Deinit()
+
+	mappedAlmanax, err := almDb.GetAlmanaxByDateRange(req.GetDate(), req.GetDate())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(mappedAlmanax) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no almanax found for %s", req.GetDate())
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	grpcRequest()
+	return toPbAlmanaxDay(&mappedAlmanax[0], lang, level, txn)
+}
+
+func (s *almanaxServer) ListAlmanax(req *pb.ListAlmanaxRequest, stream grpc.ServerStreamingServer[pb.AlmanaxDay]) error {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return err
+	}
+
+	level, err := grpcLevel(req.Level)
+	if err != nil {
+		return err
+	}
+
+	fromStr := req.GetFrom()
+	toStr := req.GetTo()
+	if toStr == "" {
+		toStr = fromStr
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "from must be formatted as YYYY-MM-DD")
+	}
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "to must be formatted as YYYY-MM-DD")
+	}
+	if from.After(to) {
+		return status.Error(codes.InvalidArgument, "from date is after to date")
+	}
+	if to.Sub(from).Hours() > float64(config.AlmanaxMaxLookAhead)*24 {
+		return status.Error(codes.InvalidArgument, "date range is too large")
+	}
+
+	almDb := database.NewDatabaseRepository(context.Background(), config.DbDir)
+	defer almDb.Deinit()
+
+	var mappedAlmanax []database.MappedAlmanax
+	if req.GetBonusType() != "" {
+		mappedAlmanax, err = almDb.GetAlmanaxByDateRangeAndNameID(fromStr, toStr, req.GetBonusType())
+	} else {
+		mappedAlmanax, err = almDb.GetAlmanaxByDateRange(fromStr, toStr)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	grpcRequest()
+	for i := range mappedAlmanax {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		day, err := toPbAlmanaxDay(&mappedAlmanax[i], lang, level, txn)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *almanaxServer) ListBonusTypes(req *pb.ListRequest, stream grpc.ServerStreamingServer[pb.AlmanaxBonusType]) error {
+	lang, err := grpcLang(req.GetLang())
+	if err != nil {
+		return err
+	}
+
+	almDb := database.NewDatabaseRepository(context.Background(), config.DbDir)
+	defer almDb.Deinit()
+
+	bonusTypes, err := almDb.GetBonusTypes()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	grpcRequest()
+	for _, bonusType := range almanax.BonusListingsToBonusIdTranslated(bonusTypes, lang) {
+		if err := stream.Send(&pb.AlmanaxBonusType{Id: bonusType.Id, Name: bonusType.Name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
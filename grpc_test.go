@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	"github.com/dofusdude/doduapi/pb"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestToPbConditionNode(t *testing.T) {
+	relation := "and"
+	tree := &ApiConditionNode{
+		IsOperand: false,
+		Relation:  &relation,
+		Children: []*ApiConditionNode{
+			{IsOperand: true, Condition: &ApiCondition{Operator: ">", IntValue: 100, Element: ApiConditionType{Id: 7, Name: "Strength"}}},
+			{IsOperand: true, Condition: &ApiCondition{Operator: "<", IntValue: 50, Element: ApiConditionType{Id: 9, Name: "Agility"}}},
+		},
+	}
+
+	node := toPbConditionNode(tree)
+	if node.Relation != "and" {
+		t.Error("Expected and, got ", node.Relation)
+	}
+
+	if len(node.Children) != 2 {
+		t.Fatal("Expected 2 children, got ", len(node.Children))
+	}
+
+	if node.Children[1].Condition.IntValue != 50 || node.Children[1].Condition.Element.Id != 9 {
+		t.Error("Expected second condition to be < 50 on element 9, got ", node.Children[1].Condition)
+	}
+
+	if toPbConditionNode(nil) != nil {
+		t.Error("Expected nil for a missing condition tree")
+	}
+}
+
+// newGrpcTestClient serves the gRPC services over an in-memory connection, backed by a database with two resources
+// and a set.
+func newGrpcTestClient(t *testing.T) pb.EncyclopediaClient {
+	config.SetLanguages([]string{"de", "en", "es", "fr", "pt"})
+	t.Cleanup(func() { config.SetLanguages(nil) })
+
+	db, err := memdb.NewMemDB(GetMemDBSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	version := utils.CurrentRedBlueVersionStr(database.Version.MemDb)
+	txn := db.Txn(true)
+	for _, item := range []*mapping.MappedMultilangItemUnity{
+		{AnkamaId: 1, Name: map[string]string{"en": "Wool", "fr": "Laine"}, Type: mapping.MappedMultilangItemTypeUnity{CategoryId: 2}},
+		{AnkamaId: 2, Name: map[string]string{"en": "Wood", "fr": "Bois"}, Type: mapping.MappedMultilangItemTypeUnity{CategoryId: 2}},
+	} {
+		for _, table := range []string{"resources", "all_items"} {
+			if err = txn.Insert(fmt.Sprintf("%s-%s", version, table), item); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err = txn.Insert(fmt.Sprintf("%s-sets", version), &mapping.MappedMultilangSetUnity{AnkamaId: 10, Name: map[string]string{"en": "Gobball Set"}}); err != nil {
+		t.Fatal(err)
+	}
+	txn.Commit()
+
+	previousDb := database.Db
+	database.Db = db
+	t.Cleanup(func() { database.Db = previousDb })
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewGrpcServer()
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewEncyclopediaClient(conn)
+}
+
+func TestGrpcGetItem(t *testing.T) {
+	client := newGrpcTestClient(t)
+	ctx := context.Background()
+
+	item, err := client.GetItem(ctx, &pb.GetItemRequest{Lang: "fr", AnkamaId: 1, Category: pb.ItemCategory_ITEM_CATEGORY_RESOURCES})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.AnkamaId != 1 || item.Name != "Laine" {
+		t.Error("Expected Laine with id 1, got ", item.AnkamaId, item.Name)
+	}
+
+	cases := []struct {
+		req  *pb.GetItemRequest
+		code codes.Code
+	}{
+		{&pb.GetItemRequest{AnkamaId: 99}, codes.NotFound},
+		{&pb.GetItemRequest{AnkamaId: 1, Category: pb.ItemCategory_ITEM_CATEGORY_EQUIPMENT}, codes.NotFound},
+		{&pb.GetItemRequest{Lang: "xx", AnkamaId: 1}, codes.InvalidArgument},
+		{&pb.GetItemRequest{AnkamaId: 1, Category: pb.ItemCategory(42)}, codes.InvalidArgument},
+	}
+	for _, c := range cases {
+		if _, err := client.GetItem(ctx, c.req); status.Code(err) != c.code {
+			t.Error("Expected ", c.code, " for ", c.req, ", got ", err)
+		}
+	}
+}
+
+func TestGrpcGetSet(t *testing.T) {
+	client := newGrpcTestClient(t)
+
+	set, err := client.GetSet(context.Background(), &pb.GetByIdRequest{AnkamaId: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Name != "Gobball Set" {
+		t.Error("Expected the english name without a language, got ", set.Name)
+	}
+
+	if _, err = client.GetSet(context.Background(), &pb.GetByIdRequest{AnkamaId: 11}); status.Code(err) != codes.NotFound {
+		t.Error("Expected NotFound for a missing set, got ", err)
+	}
+}
+
+func TestGrpcListItems(t *testing.T) {
+	client := newGrpcTestClient(t)
+
+	stream, err := client.ListItems(context.Background(), &pb.ListItemsRequest{Category: pb.ItemCategory_ITEM_CATEGORY_RESOURCES})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for {
+		item, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, item.Name)
+	}
+	if len(names) != 2 || names[0] != "Wool" || names[1] != "Wood" {
+		t.Error("Expected Wool and Wood in id order, got ", names)
+	}
+
+	// errors of a stream arrive with the first message
+	stream, err = client.ListItems(context.Background(), &pb.ListItemsRequest{Lang: "xx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Error("Expected InvalidArgument for an unknown language, got ", err)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	e "github.com/dofusdude/doduapi/errmsg"
 	"github.com/dofusdude/doduapi/utils"
 	mapping "github.com/dofusdude/dodumap"
+	"github.com/go-chi/chi/v5"
 	"github.com/hashicorp/go-memdb"
 	"github.com/meilisearch/meilisearch-go"
 	g "github.com/zyedidia/generic"
@@ -204,7 +206,6 @@ func ListAllCosmetics(w http.ResponseWriter, r *http.Request) {
 
 func ListMounts(w http.ResponseWriter, r *http.Request) {
 	lang := r.Context().Value("lang").(string)
-	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
 
 	filterFamilyName := r.URL.Query().Get("filter[family.name]")
 	filterFamilyIdStr := r.URL.Query().Get("filter[family.id]")
@@ -215,9 +216,41 @@ func ListMounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var filterFamilyId int
+	if filterFamilyIdStr != "" {
+		var err error
+		filterFamilyId, err = strconv.Atoi(filterFamilyIdStr)
+		if err != nil {
+			e.WriteInvalidFilterResponse(w, "filter[family.id] is not a number.")
+			return
+		}
+	}
+
+	effectFilters, err := parseEffectFilters(r.URL.Query())
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	sortKeys, err := parseSortParam(r.URL.Query().Get("sort"), "", mountSortFields)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
+	if err = validateEffectFilters(effectFilters, txn); err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	if err = validateSortKeys(sortKeys, txn); err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
 	equipIt, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "equipment"), "id")
 	if err != nil || equipIt == nil {
 		e.WriteNotFoundResponse(w, "No mounts found.")
@@ -227,7 +260,7 @@ func ListMounts(w http.ResponseWriter, r *http.Request) {
 	utils.RequestsTotal.Inc()
 	utils.RequestsMountsList.Inc()
 
-	var mounts []APIMount
+	var mounts []*mapping.MappedMultilangItemUnity
 	for obj := equipIt.Next(); obj != nil; obj = equipIt.Next() {
 		p := obj.(*mapping.MappedMultilangItemUnity)
 		if !mountEquipmentTypeIds[p.Type.ItemTypeId] {
@@ -239,56 +272,59 @@ func ListMounts(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		if filterFamilyIdStr != "" {
-			filterFamilyId, err := strconv.Atoi(filterFamilyIdStr)
-			if err != nil {
-				e.WriteInvalidFilterResponse(w, "filter[family.id] is not a number.")
-				return
-			}
 			if p.Type.ItemTypeId != filterFamilyId {
 				continue
 			}
 		}
-		mount := RenderEquipmentAsMountListEntry(p, lang)
-		if expansions.Has("effects") {
-			effects := RenderEffects(&p.Effects, lang)
-			if len(effects) != 0 {
-				mount.Effects = effects
-			}
+		if !matchesEffectFilters(p.Effects, effectFilters) {
+			continue
 		}
-		mounts = append(mounts, mount)
+		mounts = append(mounts, p)
 	}
 
-	total := len(mounts)
-	if total == 0 {
+	if len(mounts) == 0 {
 		e.WriteNotFoundResponse(w, "No mounts left after filtering.")
 		return
 	}
 
-	if pagination.ValidatePagination(total) != 0 {
-		e.WriteInvalidQueryResponse(w, "Invalid pagination parameters.")
+	newEntitySorter(sortKeys, lang).SortMounts(mounts)
+
+	if isStreamed(r) {
+		writeStream(w, r, "mounts", len(mounts), func(i int) any {
+			return utils.RenderLocalized(r, func(lang string) any {
+				return RenderMountListEntryExpanded(mounts[i], lang, expansions)
+			})
+		})
 		return
 	}
 
-	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
-	links, _ := pagination.BuildLinks(*r.URL, total, config.ApiScheme, config.ApiHostName)
-	paginatedMounts := mounts[startIdx:endIdx]
-
-	response := APIPageMount{
-		Items: paginatedMounts,
-		Links: links,
+	startIdx, endIdx, links, ok := pageBounds(w, r, mounts, sortKeys, func(mount *mapping.MappedMultilangItemUnity) int {
+		return mount.AnkamaId
+	})
+	if !ok {
+		return
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(response)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		paginatedMounts := make([]APIMount, 0, endIdx-startIdx)
+		for _, p := range mounts[startIdx:endIdx] {
+			paginatedMounts = append(paginatedMounts, RenderMountListEntryExpanded(p, lang, expansions))
+		}
+
+		return APIPageMount{
+			Items: paginatedMounts,
+			Links: links,
+		}
+	}))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
 
 func ListSets(w http.ResponseWriter, r *http.Request) {
 	lang := r.Context().Value("lang").(string)
-	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
 
 	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[set]"))
 	expansions := parseFields(expansionsParam)
@@ -307,9 +343,54 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var filterIsCosmetic bool
+	if filterContainsCosmeticsStr != "" {
+		filterIsCosmetic, err = strconv.ParseBool(filterContainsCosmeticsStr)
+		if err != nil {
+			e.WriteInvalidFilterResponse(w, "filter[contains_cosmetics] is not a boolean.")
+			return
+		}
+	}
+
+	var filterIsCosmeticOnly bool
+	if filterContainsCosmeticsOnlyStr != "" {
+		filterIsCosmeticOnly, err = strconv.ParseBool(filterContainsCosmeticsOnlyStr)
+		if err != nil {
+			e.WriteInvalidFilterResponse(w, "filter[contains_cosmetics_only] is not a boolean.")
+			return
+		}
+	}
+
+	effectFilters, err := parseEffectFilters(r.URL.Query())
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	sortKeys, err := parseSortParam(r.URL.Query().Get("sort"), sortLevel, setSortFields)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
+	includes, ok := requestInclude(w, r, includeTypeSets)
+	if !ok {
+		return
+	}
+
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
+	if err = validateEffectFilters(effectFilters, txn); err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	if err = validateSortKeys(sortKeys, txn); err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
 	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "sets"), "id")
 	if err != nil || it == nil {
 		e.WriteNotFoundResponse(w, "No sets found.")
@@ -319,29 +400,17 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 	utils.RequestsTotal.Inc()
 	utils.RequestsSetsList.Inc()
 
-	var sets []APIListSet
+	var sets []*mapping.MappedMultilangSetUnity
 	for obj := it.Next(); obj != nil; obj = it.Next() {
 		p := obj.(*mapping.MappedMultilangSetUnity)
 
 		if filterContainsCosmeticsOnlyStr != "" {
-			filterIsCosmetic, err := strconv.ParseBool(filterContainsCosmeticsOnlyStr)
-			if err != nil {
-				e.WriteInvalidFilterResponse(w, "filter[contains_cosmetics_only] is not a boolean.")
-				return
-			}
-
-			if p.ContainsCosmeticsOnly != filterIsCosmetic {
+			if p.ContainsCosmeticsOnly != filterIsCosmeticOnly {
 				continue
 			}
 		}
 
 		if filterContainsCosmeticsStr != "" {
-			filterIsCosmetic, err := strconv.ParseBool(filterContainsCosmeticsStr)
-			if err != nil {
-				e.WriteInvalidFilterResponse(w, "filter[contains_cosmetics] is not a boolean.")
-				return
-			}
-
 			if p.ContainsCosmetics != filterIsCosmetic {
 				continue
 			}
@@ -359,59 +428,56 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		set := RenderSetListEntry(p, lang)
-
-		if expansions.Has("effects") {
-			set.Effects = make(map[int][]ApiEffect, 0)
-			for itemCombination, effect := range p.Effects {
-				set.Effects[itemCombination] = RenderEffects(&effect, lang)
-			}
-		}
-
-		if expansions.Has("equipment_ids") {
-			set.ItemIds = p.ItemIds
+		if len(effectFilters) != 0 && !matchesEffectFilters(setEffects(p), effectFilters) {
+			continue
 		}
 
-		sets = append(sets, set)
+		sets = append(sets, p)
 	}
 
-	total := len(sets)
-	if total == 0 {
+	if len(sets) == 0 {
 		e.WriteNotFoundResponse(w, "No sets left after filtering.")
 		return
 	}
 
-	if sortLevel != "" {
-		if sortLevel == "asc" {
-			sort.Slice(sets, func(i, j int) bool {
-				return sets[i].Level < sets[j].Level
-			})
-		}
-		if sortLevel == "desc" {
-			sort.Slice(sets, func(i, j int) bool {
-				return sets[i].Level > sets[j].Level
+	newEntitySorter(sortKeys, lang).SortSets(sets)
+
+	if isStreamed(r) {
+		writeStream(w, r, "sets", len(sets), func(i int) any {
+			return utils.RenderLocalized(r, func(lang string) any {
+				return RenderSetListEntryExpanded(sets[i], lang, expansions)
 			})
-		}
+		})
+		return
 	}
 
-	if pagination.ValidatePagination(total) != 0 {
-		e.WriteInvalidQueryResponse(w, "Invalid pagination parameters.")
+	startIdx, endIdx, links, ok := pageBounds(w, r, sets, sortKeys, func(set *mapping.MappedMultilangSetUnity) int {
+		return set.AnkamaId
+	})
+	if !ok {
 		return
 	}
 
-	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
-	links, _ := pagination.BuildLinks(*r.URL, total, config.ApiScheme, config.ApiHostName)
-	paginatedSets := sets[startIdx:endIdx]
-
-	response := APIPageSet{
-		Items: paginatedSets,
-		Links: links,
+	included := newIncluder(includes, txn)
+	if err = included.addSets(sets[startIdx:endIdx]...); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(response)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		paginatedSets := make([]APIListSet, 0, endIdx-startIdx)
+		for _, p := range sets[startIdx:endIdx] {
+			paginatedSets = append(paginatedSets, RenderSetListEntryExpanded(p, lang, expansions))
+		}
+
+		return APIPageSet{
+			Items: paginatedSets,
+			Links: links,
+		}
+	}), included, nil))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -433,8 +499,8 @@ func setFilter(in *set.Set[string], prefix string, exceptions *[]string) (set.Se
 	}
 
 	for obj := it.Next(); obj != nil; obj = it.Next() {
-		effectElement := obj.(*ItemTypeId)
-		typeIds.Put(effectElement.EnName)
+		itemType := obj.(*ItemTypeDbEntry)
+		typeIds.Put(itemType.Slug)
 	}
 
 	out := set.NewHashset(10, g.Equals[string], g.HashString)
@@ -524,7 +590,6 @@ func validateFields(expansions *set.Set[string], list []string) bool {
 
 func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 	lang := r.Context().Value("lang").(string)
-	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
 
 	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[item]"))
 	var expansions *set.Set[string]
@@ -565,9 +630,60 @@ func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	effectFilters, err := parseEffectFilters(r.URL.Query())
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	sortKeys, err := parseSortParam(r.URL.Query().Get("sort"), sortLevel, itemSortFields)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
+	facetFields, ok := requestFacets(w, r)
+	if !ok {
+		return
+	}
+
+	var filterIds map[int]int // id to position in the request
+	if filterIdsParam := r.URL.Query().Get("filter[ids]"); filterIdsParam != "" {
+		ids, err := parseIdsFilter(filterIdsParam)
+		if err != nil {
+			e.WriteInvalidFilterResponse(w, err.Error())
+			return
+		}
+
+		filterIds = make(map[int]int, len(ids))
+		for i, id := range ids {
+			filterIds[id] = i
+		}
+
+		// a fixed id list fits on one page unless the client asks for something else
+		if !r.URL.Query().Has("page[size]") && r.Context().Value("cursor") == nil {
+			r = r.WithContext(context.WithValue(r.Context(), "pagination", "1,-1"))
+		}
+	}
+
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
+	if err = validateEffectFilters(effectFilters, txn); err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	if err = validateSortKeys(sortKeys, txn); err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
 	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), itemType), "id")
 	if err != nil || it == nil {
 		e.WriteNotFoundResponse(w, "No items found.")
@@ -577,7 +693,8 @@ func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 	utils.RequestsItemsList.Inc()
 	utils.RequestsTotal.Inc()
 
-	var items []APIListItem
+	facets := newFacetCounts(facetFields)
+	var items []*mapping.MappedMultilangItemUnity
 	for obj := it.Next(); obj != nil; obj = it.Next() {
 		p := obj.(*mapping.MappedMultilangItemUnity)
 
@@ -603,83 +720,18 @@ func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		item := RenderItemListEntry(p, lang)
-		// items extra fields
-		if expansions.Has("recipe") {
-			recipe, exists := GetRecipeIfExists(item.Id, txn)
-			if exists {
-				item.Recipe = RenderRecipe(recipe, database.Db)
-			} else {
-				item.Recipe = nil
-			}
-		}
-
-		if expansions.Has("description") {
-			description := p.Description[lang]
-			item.Description = &description
-		}
-
-		if expansions.Has("conditions") {
-			if p.Conditions != nil {
-				item.Conditions = RenderConditionTree(p.Conditions, lang)
-			}
-		}
-
-		if expansions.Has("effects") {
-			if p.Effects != nil {
-				renderedEffects := RenderEffects(&p.Effects, lang)
-				if len(renderedEffects) != 0 {
-					item.Effects = renderedEffects
-				}
-			}
-		}
-
-		if expansions.Has("pods") {
-			item.Pods = &p.Pods
-		}
-
-		// equipment extra fields
-		mIsWeapon := p.Type.SuperTypeId == 2 // is weapon
-		if expansions.Has("is_weapon") {
-			item.IsWeapon = &mIsWeapon
-		}
-
-		if expansions.Has("parent_set") {
-			if p.HasParentSet {
-				item.ParentSet = &APISetReverseLink{
-					Id:   p.ParentSet.Id,
-					Name: p.ParentSet.Name[lang],
-				}
-			}
+		if !matchesEffectFilters(p.Effects, effectFilters) {
+			continue
 		}
 
-		// weapon extra fields
-		if mIsWeapon {
-			if expansions.Has("critical_hit_probability") {
-				item.CriticalHitProbability = &p.CriticalHitProbability
-			}
-
-			if expansions.Has("critical_hit_bonus") {
-				item.CriticalHitBonus = &p.CriticalHitBonus
-			}
-
-			if expansions.Has("max_cast_per_turn") {
-				item.MaxCastPerTurn = &p.MaxCastPerTurn
-			}
-
-			if expansions.Has("ap_cost") {
-				item.ApCost = &p.ApCost
-			}
-
-			if expansions.Has("range") {
-				item.Range = &APIRange{
-					Min: p.MinRange,
-					Max: p.Range,
-				}
+		if filterIds != nil {
+			if _, ok := filterIds[p.AnkamaId]; !ok {
+				continue
 			}
 		}
 
-		items = append(items, item)
+		items = append(items, p)
+		facets.addItem(p)
 	}
 
 	if len(items) == 0 {
@@ -687,39 +739,55 @@ func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if sortLevel != "" {
-		if sortLevel == "asc" {
-			sort.Slice(items, func(i, j int) bool {
-				return items[i].Level < items[j].Level
-			})
-		}
-		if sortLevel == "desc" {
-			sort.Slice(items, func(i, j int) bool {
-				return items[i].Level > items[j].Level
-			})
-		}
+	if filterIds != nil && len(sortKeys) == 0 {
+		sort.SliceStable(items, func(i, j int) bool {
+			return filterIds[items[i].AnkamaId] < filterIds[items[j].AnkamaId]
+		})
+	} else {
+		newEntitySorter(sortKeys, lang).SortItems(items)
 	}
 
-	total := len(items)
+	if isStreamed(r) {
+		writeStream(w, r, "items", len(items), func(i int) any {
+			return utils.RenderLocalized(r, func(lang string) any {
+				return RenderItemListEntryExpanded(items[i], lang, expansions, txn)
+			})
+		})
+		return
+	}
 
-	if pagination.ValidatePagination(total) != 0 {
-		e.WriteInvalidQueryResponse(w, "Invalid pagination parameters.")
+	startIdx, endIdx, links, ok := pageBounds(w, r, items, sortKeys, func(item *mapping.MappedMultilangItemUnity) int {
+		return item.AnkamaId
+	})
+	if !ok {
 		return
 	}
 
-	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
-	links, _ := pagination.BuildLinks(*r.URL, total, config.ApiScheme, config.ApiHostName)
-	paginatedItems := items[startIdx:endIdx]
+	included := newIncluder(includes, txn)
+	if err = included.addItems(items[startIdx:endIdx]...); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
 
-	response := APIPageItem{
-		Items: paginatedItems,
-		Links: links,
+	if err = facets.loadNames(txn); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(response)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		paginatedItems := make([]APIListItem, 0, endIdx-startIdx)
+		for _, p := range items[startIdx:endIdx] {
+			paginatedItems = append(paginatedItems, RenderItemListEntryExpanded(p, lang, expansions, txn))
+		}
+
+		return APIPageItem{
+			Items: paginatedItems,
+			Links: links,
+		}
+	}), included, facets))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -836,7 +904,7 @@ func SearchMounts(w http.ResponseWriter, r *http.Request) {
 		Id float64 `json:"id"`
 	}
 
-	var mounts []APIMount
+	var mounts []*mapping.MappedMultilangItemUnity
 	for _, hitRaw := range searchResp.Hits {
 		hit := Hit{}
 		err = hitRaw.DecodeInto(&hit)
@@ -855,14 +923,19 @@ func SearchMounts(w http.ResponseWriter, r *http.Request) {
 			e.WriteNotFoundResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", "mount", strconv.Itoa(itemId)))
 			return
 		}
-		item := raw.(*mapping.MappedMultilangItemUnity)
-		mounts = append(mounts, RenderEquipmentAsMountListEntry(item, lang))
+		mounts = append(mounts, raw.(*mapping.MappedMultilangItemUnity))
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(mounts)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		var rendered []APIMount
+		for _, item := range mounts {
+			rendered = append(rendered, RenderEquipmentAsMountListEntry(item, lang))
+		}
+		return rendered
+	}))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -920,6 +993,11 @@ func SearchSets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	includes, ok := requestInclude(w, r, includeTypeSets)
+	if !ok {
+		return
+	}
+
 	index := client.Index(fmt.Sprintf("%s-sets-%s", utils.CurrentRedBlueVersionStr(database.Version.Search), lang))
 	var request *meilisearch.SearchRequest
 
@@ -951,7 +1029,7 @@ func SearchSets(w http.ResponseWriter, r *http.Request) {
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
-	var sets []APIListSet
+	var sets []*mapping.MappedMultilangSetUnity
 	for _, hitRaw := range searchResp.Hits {
 		hit := Hit{}
 		err = hitRaw.DecodeInto(&hit)
@@ -972,18 +1050,35 @@ func SearchSets(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		item := raw.(*mapping.MappedMultilangSetUnity)
-		sets = append(sets, RenderSetListEntry(item, lang))
+		sets = append(sets, raw.(*mapping.MappedMultilangSetUnity))
+	}
+
+	included := newIncluder(includes, txn)
+	if err = included.addSets(sets...); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(sets)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		var rendered []APIListSet
+		for _, item := range sets {
+			rendered = append(rendered, RenderSetListEntry(item, lang))
+		}
+		return rendered
+	}), included, nil))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
 
+// allSearchResults are the results of one index for SearchAllIndices, or the error that stopped its search.
+type allSearchResults struct {
+	results []ApiAllSearchResultScore
+	err     error
+}
+
 func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 	client := meilisearch.New(config.MeiliHost, meilisearch.WithAPIKey(config.MeiliKey))
 	defer client.Close()
@@ -1012,6 +1107,13 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// facets count the items the query matches, sets and mounts have none of the fields
+	facetFields, ok := requestFacets(w, r)
+	if !ok {
+		return
+	}
+	facets := newFacetCounts(facetFields)
+
 	lang := r.Context().Value("lang").(string)
 
 	var searchLimit int64
@@ -1066,12 +1168,12 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 	wordScoreWeight := 0.5
 	typoScoreWeight := 0.5
 
-	searchChans := make([]chan []ApiAllSearchResultScore, 0)
+	searchChans := make([]chan allSearchResults, 0)
 
 	indicesHasItem := parsedIndices.Has("items-equipment") || parsedIndices.Has("items-consumables") || parsedIndices.Has("items-resources") || parsedIndices.Has("items-quest_items") || parsedIndices.Has("items-cosmetics") || parsedIndices.Has("mounts")
 	needItemSearch := parsedIndices.Size() == 0 || indicesHasItem
 	if needItemSearch {
-		itemRetChan := make(chan []ApiAllSearchResultScore)
+		itemRetChan := make(chan allSearchResults)
 		searchChans = append(searchChans, itemRetChan)
 
 		go func() {
@@ -1082,11 +1184,17 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 				Limit:                   searchLimit * 3,
 				Filter:                  filterString,
 				ShowRankingScoreDetails: true,
+				Facets:                  facets.searchFacets(),
+			}
+
+			searchResp, err := index.Search(query, request)
+			if err != nil {
+				itemRetChan <- allSearchResults{err: fmt.Errorf("Failed to search for query: %w", err)}
+				return
 			}
 
-			var searchResp *meilisearch.SearchResponse
-			if searchResp, err = index.Search(query, request); err != nil {
-				e.WriteServerErrorResponse(w, "Failed to search for query: "+err.Error())
+			if err = facets.addSearchResponse(searchResp); err != nil {
+				itemRetChan <- allSearchResults{err: fmt.Errorf("Could not decode facets: %w", err)}
 				return
 			}
 
@@ -1095,7 +1203,7 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 				indexed := Hit{}
 				err = hitRaw.DecodeInto(&indexed)
 				if err != nil {
-					e.WriteServerErrorResponse(w, "Could not decode hit: "+err.Error())
+					itemRetChan <- allSearchResults{err: fmt.Errorf("Could not decode hit: %w", err)}
 					return
 				}
 
@@ -1106,8 +1214,7 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 				raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "all_items"), "id", itemId)
 
 				if err != nil {
-					e.WriteServerErrorResponse(w, "Could not find item in database: "+err.Error())
-					itemRetChan <- nil
+					itemRetChan <- allSearchResults{err: fmt.Errorf("Could not find item in database: %w", err)}
 					return
 				}
 
@@ -1130,8 +1237,7 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 				case 5:
 					itemType += "cosmetics"
 				default:
-					e.WriteServerErrorResponse(w, "Unknown stuff type: "+strconv.Itoa(item.Type.SuperTypeId))
-					itemRetChan <- nil
+					itemRetChan <- allSearchResults{err: fmt.Errorf("Unknown stuff type: %d", item.Type.SuperTypeId)}
 					return
 				}
 
@@ -1141,42 +1247,44 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 					continue
 				}
 
-				itemFields := RenderItemListEntry(item, lang)
+				render := func(lang string) ApiAllSearchResult {
+					itemFields := RenderItemListEntry(item, lang)
 
-				itemInclude := &ApiAllSearchItem{}
+					itemInclude := &ApiAllSearchItem{}
 
-				if itemExpansions.Has("type") {
-					itemInclude.Type = &itemFields.Type
-				}
+					if itemExpansions.Has("type") {
+						itemInclude.Type = &itemFields.Type
+					}
 
-				if itemExpansions.Has("level") {
-					itemInclude.Level = &itemFields.Level
-				}
+					if itemExpansions.Has("level") {
+						itemInclude.Level = &itemFields.Level
+					}
 
-				if itemExpansions.Has("image_urls") {
-					itemInclude.ImageUrls = &itemFields.ImageUrls
-				}
+					if itemExpansions.Has("image_urls") {
+						itemInclude.ImageUrls = &itemFields.ImageUrls
+					}
 
-				if itemInclude.ImageUrls == nil && itemInclude.Type == nil && itemInclude.Level == nil {
-					itemInclude = nil
-				}
+					if itemInclude.ImageUrls == nil && itemInclude.Type == nil && itemInclude.Level == nil {
+						itemInclude = nil
+					}
 
-				result := ApiAllSearchResult{
-					Id:   item.AnkamaId,
-					Name: item.Name[lang],
-					Type: ApiAllSearchResultType{
-						NameId: itemType,
-					},
-					ItemFields: itemInclude,
+					return ApiAllSearchResult{
+						Id:   item.AnkamaId,
+						Name: item.Name[lang],
+						Type: ApiAllSearchResultType{
+							NameId: itemType,
+						},
+						ItemFields: itemInclude,
+					}
 				}
 
 				items = append(items, ApiAllSearchResultScore{
-					Result: result,
+					Render: render,
 					Score:  score,
 				})
 			}
 
-			itemRetChan <- items
+			itemRetChan <- allSearchResults{results: items}
 		}()
 	}
 
@@ -1193,7 +1301,7 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if needSetSearch {
-		setRetChan := make(chan []ApiAllSearchResultScore)
+		setRetChan := make(chan allSearchResults)
 		searchChans = append(searchChans, setRetChan)
 		go func() {
 			setIndexUid := fmt.Sprintf("%s-sets-%s", utils.CurrentRedBlueVersionStr(database.Version.Search), lang)
@@ -1205,10 +1313,9 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 				ShowRankingScoreDetails: true,
 			}
 
-			var searchResp *meilisearch.SearchResponse
-			if searchResp, err = setIndex.Search(query, request); err != nil {
-				e.WriteServerErrorResponse(w, "Failed to search for query: "+err.Error())
-				setRetChan <- nil
+			searchResp, err := setIndex.Search(query, request)
+			if err != nil {
+				setRetChan <- allSearchResults{err: fmt.Errorf("Failed to search for query: %w", err)}
 				return
 			}
 
@@ -1217,7 +1324,7 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 				indexed := Hit{}
 				err = hitRaw.DecodeInto(&indexed)
 				if err != nil {
-					e.WriteServerErrorResponse(w, "Could not decode hit: "+err.Error())
+					setRetChan <- allSearchResults{err: fmt.Errorf("Could not decode hit: %w", err)}
 					return
 				}
 
@@ -1228,47 +1335,53 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 				txn := database.Db.Txn(false)
 				raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "sets"), "id", setId)
 				if err != nil {
-					e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
-					setRetChan <- nil
+					setRetChan <- allSearchResults{err: fmt.Errorf("Could not read database: %w", err)}
 					return
 				}
 
 				if raw == nil {
-					e.WriteServerErrorResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", "set", strconv.Itoa(setId)))
-					setRetChan <- nil
+					setRetChan <- allSearchResults{err: fmt.Errorf("Could not find %s with ID %s in database", "set", strconv.Itoa(setId))}
 					return
 				}
 
 				item := raw.(*mapping.MappedMultilangSetUnity)
 
-				result := ApiAllSearchResult{
-					Name: item.Name[lang],
-					Id:   item.AnkamaId,
-					Type: ApiAllSearchResultType{
-						NameId: "sets",
-					},
-					ItemFields: nil,
+				render := func(lang string) ApiAllSearchResult {
+					return ApiAllSearchResult{
+						Name: item.Name[lang],
+						Id:   item.AnkamaId,
+						Type: ApiAllSearchResultType{
+							NameId: "sets",
+						},
+						ItemFields: nil,
+					}
 				}
 
 				sets = append(sets, ApiAllSearchResultScore{
-					Result: result,
+					Render: render,
 					Score:  score,
 				})
 			}
 
-			setRetChan <- sets
+			setRetChan <- allSearchResults{results: sets}
 		}()
 	}
 
-	// wait for all search results and merge them into one slice
+	// wait for all search results and merge them into one slice, errors are written here so there is one response
 	var merged []ApiAllSearchResultScore
-
+	var searchErr error
 	for _, searchChan := range searchChans {
 		searchResults := <-searchChan
-		if searchResults == nil {
-			return
+		if searchResults.err != nil {
+			searchErr = searchResults.err
+			continue
 		}
-		merged = append(merged, searchResults...)
+		merged = append(merged, searchResults.results...)
+	}
+
+	if searchErr != nil {
+		e.WriteServerErrorResponse(w, searchErr.Error())
+		return
 	}
 
 	if len(merged) == 0 {
@@ -1286,15 +1399,24 @@ func SearchAllIndices(w http.ResponseWriter, r *http.Request) {
 		merged = merged[:searchLimit]
 	}
 
-	var stuffs []ApiAllSearchResult
-	for _, item := range merged {
-		stuffs = append(stuffs, item.Result)
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	if err = facets.loadNames(txn); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(stuffs)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		var stuffs []ApiAllSearchResult
+		for _, item := range merged {
+			stuffs = append(stuffs, item.Render(lang))
+		}
+		return stuffs
+	}), nil, facets))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -1339,6 +1461,17 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
+	facetFields, ok := requestFacets(w, r)
+	if !ok {
+		return
+	}
+	facets := newFacetCounts(facetFields)
+
 	if additiveTypes.Size() > 0 {
 		if filterString != "" {
 			filterString += " AND "
@@ -1366,12 +1499,14 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 
 	if filterString == "" {
 		request = &meilisearch.SearchRequest{
-			Limit: searchLimit,
+			Limit:  searchLimit,
+			Facets: facets.searchFacets(),
 		}
 	} else {
 		request = &meilisearch.SearchRequest{
 			Limit:  searchLimit,
 			Filter: filterString,
+			Facets: facets.searchFacets(),
 		}
 	}
 
@@ -1389,11 +1524,15 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if err = facets.addSearchResponse(searchResp); err != nil {
+		e.WriteServerErrorResponse(w, "Could not decode facets: "+err.Error())
+		return
+	}
+
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
-	var items []APIListItem
-	var typedItems []APIListTypedItem
+	var found []*mapping.MappedMultilangItemUnity
 	for _, hitRaw := range searchResp.Hits {
 		indexed := Hit{}
 		err = hitRaw.DecodeInto(&indexed)
@@ -1421,10 +1560,32 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 			continue
 		}
 
-		item := raw.(*mapping.MappedMultilangItemUnity)
+		found = append(found, raw.(*mapping.MappedMultilangItemUnity))
+	}
+
+	included := newIncluder(includes, txn)
+	if err = included.addItems(found...); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	if err = facets.loadNames(txn); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	encodeErr := utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
 		if all {
-			typedItems = append(typedItems, RenderTypedItemListEntry(item, lang))
-		} else {
+			var typedItems []APIListTypedItem
+			for _, item := range found {
+				typedItems = append(typedItems, RenderTypedItemListEntry(item, lang))
+			}
+			return typedItems
+		}
+
+		var items []APIListItem
+		for _, item := range found {
 			itemRendered := RenderItemListEntry(item, lang)
 			recipe, exists := GetRecipeIfExists(itemRendered.Id, txn)
 			if exists {
@@ -1432,17 +1593,10 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 			}
 			items = append(items, itemRendered)
 		}
-	}
-
-	utils.WriteCacheHeader(&w)
-	var encodeErr error
-	if all {
-		encodeErr = json.NewEncoder(w).Encode(typedItems)
-	} else {
-		encodeErr = json.NewEncoder(w).Encode(items)
-	}
+		return items
+	}), included, facets))
 	if encodeErr != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -1474,9 +1628,13 @@ func SearchAllItems(w http.ResponseWriter, r *http.Request) {
 // single
 
 func GetSingleSetHandler(w http.ResponseWriter, r *http.Request) {
-	lang := r.Context().Value("lang").(string)
 	ankamaId := r.Context().Value("ankamaId").(int)
 
+	includes, ok := requestInclude(w, r, includeTypeSets)
+	if !ok {
+		return
+	}
+
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
@@ -1494,17 +1652,24 @@ func GetSingleSetHandler(w http.ResponseWriter, r *http.Request) {
 	utils.RequestsTotal.Inc()
 	utils.RequestsSetsSingle.Inc()
 
-	set := RenderSet(raw.(*mapping.MappedMultilangSetUnity), lang)
+	set := raw.(*mapping.MappedMultilangSetUnity)
+	included := newIncluder(includes, txn)
+	if err = included.addSets(set); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(set)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		return RenderSet(set, lang)
+	}), included, nil))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
 
 func GetSingleMountHandler(w http.ResponseWriter, r *http.Request) {
-	lang := r.Context().Value("lang").(string)
 	ankamaId := r.Context().Value("ankamaId").(int)
 
 	txn := database.Db.Txn(false)
@@ -1528,19 +1693,24 @@ func GetSingleMountHandler(w http.ResponseWriter, r *http.Request) {
 	utils.RequestsTotal.Inc()
 	utils.RequestsMountsSingle.Inc()
 
-	mount := RenderEquipmentAsMount(item, lang)
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(mount)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		return RenderEquipmentAsMount(item, lang)
+	}))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
 
 func GetSingleItemWithOptionalRecipeHandler(itemType string, w http.ResponseWriter, r *http.Request) {
-	lang := r.Context().Value("lang").(string)
 	ankamaId := r.Context().Value("ankamaId").(int)
 
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
@@ -1551,22 +1721,28 @@ func GetSingleItemWithOptionalRecipeHandler(itemType string, w http.ResponseWrit
 	}
 
 	if raw == nil {
-		e.WriteNotFoundResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", itemType, strconv.Itoa(ankamaId)))
+		if !redirectToItemCategory(w, r, ankamaId, txn) {
+			e.WriteNotFoundResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", itemType, strconv.Itoa(ankamaId)))
+		}
 		return
 	}
 
 	utils.RequestsTotal.Inc()
 	utils.RequestsItemsSingle.Inc()
 
-	resource := RenderResource(raw.(*mapping.MappedMultilangItemUnity), lang)
-	recipe, exists := GetRecipeIfExists(ankamaId, txn)
-	if exists {
-		resource.Recipe = RenderRecipe(recipe, database.Db)
+	resource := raw.(*mapping.MappedMultilangItemUnity)
+	included := newIncluder(includes, txn)
+	if err = included.addItems(resource); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
 	}
+
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(resource)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		return RenderSingleItem(resource, lang, txn)
+	}), included, nil))
 	if err != nil {
-		e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
 		return
 	}
 }
@@ -1592,9 +1768,13 @@ func GetSingleEquipmentHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetSingleEquipmentLikeHandler(cosmetic bool, w http.ResponseWriter, r *http.Request) {
-	lang := r.Context().Value("lang").(string)
 	ankamaId := r.Context().Value("ankamaId").(int)
 
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
 	txn := database.Db.Txn(false)
 	defer txn.Abort()
 
@@ -1611,6 +1791,76 @@ func GetSingleEquipmentLikeHandler(cosmetic bool, w http.ResponseWriter, r *http
 		return
 	}
 
+	if raw == nil {
+		if !redirectToItemCategory(w, r, ankamaId, txn) {
+			e.WriteNotFoundResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", "item", strconv.Itoa(ankamaId)))
+		}
+		return
+	}
+
+	utils.RequestsTotal.Inc()
+	utils.RequestsItemsSingle.Inc()
+
+	equipment := raw.(*mapping.MappedMultilangItemUnity)
+	included := newIncluder(includes, txn)
+	if err = included.addItems(equipment); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		return RenderSingleItem(equipment, lang, txn)
+	}), included, nil))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
+
+// redirectToItemCategory answers with a permanent redirect if the item exists in another category than requested.
+func redirectToItemCategory(w http.ResponseWriter, r *http.Request, ankamaId int, txn *memdb.Txn) bool {
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "all_items"), "id", ankamaId)
+	if err != nil || raw == nil {
+		return false
+	}
+
+	category := utils.CategoryIdApiMapping(raw.(*mapping.MappedMultilangItemUnity).Type.CategoryId)
+	if category == "" {
+		return false
+	}
+
+	// routes without {lang} redirect to routes without it
+	location := apiBasePath()
+	if lang := chi.URLParam(r, "lang"); lang != "" {
+		location += "/" + strings.ToLower(lang)
+	}
+	location += fmt.Sprintf("/items/%s/%d", category, ankamaId)
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+
+	http.Redirect(w, r, location, http.StatusMovedPermanently)
+	return true
+}
+
+func GetSingleItemHandler(w http.ResponseWriter, r *http.Request) {
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "all_items"), "id", ankamaId)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
 	if raw == nil {
 		e.WriteNotFoundResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", "item", strconv.Itoa(ankamaId)))
 		return
@@ -1620,30 +1870,24 @@ func GetSingleEquipmentLikeHandler(cosmetic bool, w http.ResponseWriter, r *http
 	utils.RequestsItemsSingle.Inc()
 
 	item := raw.(*mapping.MappedMultilangItemUnity)
-	if item.Type.SuperTypeId == 2 { // is weapon
-		weapon := RenderWeapon(item, lang)
-		recipe, exists := GetRecipeIfExists(ankamaId, txn)
-		if exists {
-			weapon.Recipe = RenderRecipe(recipe, database.Db)
-		}
-		utils.WriteCacheHeader(&w)
-		err = json.NewEncoder(w).Encode(weapon)
-		if err != nil {
-			e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
-			return
-		}
-	} else {
-		equipment := RenderEquipment(item, lang)
-		recipe, exists := GetRecipeIfExists(ankamaId, txn)
-		if exists {
-			equipment.Recipe = RenderRecipe(recipe, database.Db)
-		}
-		utils.WriteCacheHeader(&w)
-		err = json.NewEncoder(w).Encode(equipment)
-		if err != nil {
-			e.WriteServerErrorResponse(w, "Could not encode JSON: "+err.Error())
-			return
-		}
+	included := newIncluder(includes, txn)
+	if err = included.addItems(item); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
 
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		return APITypedItem{
+			ItemSubtype: APIListItemType{
+				Id:     item.Type.CategoryId,
+				NameId: utils.CategoryIdApiMapping(item.Type.CategoryId),
+			},
+			Item: RenderSingleItem(item, lang, txn),
+		}
+	}), included, nil))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
 	}
 }
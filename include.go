@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+)
+
+// maxIncludeDepth is how many relations one include path may follow, recipe.item.parent_set.equipment follows three.
+const maxIncludeDepth = 3
+
+const (
+	includeTypeItems = "items"
+	includeTypeSets  = "sets"
+)
+
+// includeRelations lists the relations include can follow from an entity type, with the type they lead to.
+var includeRelations = map[string]map[string]string{
+	includeTypeItems: {"parent_set": includeTypeSets, "recipe.item": includeTypeItems},
+	includeTypeSets:  {"equipment": includeTypeItems},
+}
+
+// includeTree holds the requested relations and the ones to follow from there.
+type includeTree map[string]includeTree
+
+// relations returns the relations of the tree in a fixed order, so the included entities are too.
+func (tree includeTree) relations() []string {
+	relations := make([]string, 0, len(tree))
+	for relation := range tree {
+		relations = append(relations, relation)
+	}
+	sort.Strings(relations)
+	return relations
+}
+
+// parseInclude parses comma separated include paths like parent_set,recipe.item.parent_set for entities of a type.
+func parseInclude(includeParam string, entityType string) (includeTree, error) {
+	tree := make(includeTree)
+	for _, path := range strings.Split(strings.ToLower(includeParam), ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		node := tree
+		currentType := entityType
+		depth := 0
+		for rest := path; rest != ""; {
+			relation := ""
+			for candidate := range includeRelations[currentType] {
+				if rest == candidate || strings.HasPrefix(rest, candidate+".") {
+					relation = candidate
+					break
+				}
+			}
+			if relation == "" {
+				return nil, fmt.Errorf("%s has no relation %s", currentType, strings.SplitN(rest, ".", 2)[0])
+			}
+
+			depth++
+			if depth > maxIncludeDepth {
+				return nil, fmt.Errorf("%s follows more than %d relations", path, maxIncludeDepth)
+			}
+
+			child, ok := node[relation]
+			if !ok {
+				child = make(includeTree)
+				node[relation] = child
+			}
+			node = child
+			currentType = includeRelations[currentType][relation]
+			rest = strings.TrimPrefix(strings.TrimPrefix(rest, relation), ".")
+		}
+	}
+
+	if len(tree) == 0 {
+		return nil, fmt.Errorf("include needs at least one relation")
+	}
+
+	return tree, nil
+}
+
+// requestInclude parses the include parameter for a response of the given entity type. It writes the error response
+// itself and returns false if the parameter cannot be used. Without include the tree is nil.
+func requestInclude(w http.ResponseWriter, r *http.Request, entityType string) (includeTree, bool) {
+	if !r.URL.Query().Has("include") {
+		return nil, true
+	}
+
+	if isStreamed(r) {
+		e.WriteInvalidQueryResponse(w, "include cannot be used when listing all entries.")
+		return nil, false
+	}
+
+	if format, _ := r.Context().Value("format").(string); format == utils.FormatCsv || format == utils.FormatNdjson {
+		e.WriteInvalidQueryResponse(w, "include needs JSON or MessagePack responses.")
+		return nil, false
+	}
+
+	tree, err := parseInclude(r.URL.Query().Get("include"), entityType)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "include is invalid: "+err.Error())
+		return nil, false
+	}
+	return tree, true
+}
+
+type APIIncludedEntity struct {
+	Type string `json:"type"`
+	Id   int    `json:"id"`
+	Data any    `json:"data"`
+}
+
+// includer collects the related entities of a response once each, in the order they are found. Entities of the
+// response itself are never included. A nil includer collects nothing.
+type includer struct {
+	txn      *memdb.Txn
+	tree     includeTree
+	seen     map[BatchReference]bool
+	included []any // *mapping.MappedMultilangItemUnity or *mapping.MappedMultilangSetUnity
+}
+
+func newIncluder(tree includeTree, txn *memdb.Txn) *includer {
+	if tree == nil {
+		return nil
+	}
+	return &includer{
+		txn:  txn,
+		tree: tree,
+		seen: make(map[BatchReference]bool),
+	}
+}
+
+func (in *includer) addItems(items ...*mapping.MappedMultilangItemUnity) error {
+	if in == nil {
+		return nil
+	}
+	for _, item := range items {
+		in.seen[BatchReference{Type: includeTypeItems, Id: item.AnkamaId}] = true
+	}
+	for _, item := range items {
+		if err := in.followItem(item, in.tree); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *includer) addSets(sets ...*mapping.MappedMultilangSetUnity) error {
+	if in == nil {
+		return nil
+	}
+	for _, set := range sets {
+		in.seen[BatchReference{Type: includeTypeSets, Id: set.AnkamaId}] = true
+	}
+	for _, set := range sets {
+		if err := in.followSet(set, in.tree); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// include remembers an entity unless it is already part of the response.
+func (in *includer) include(entityType string, id int, entity any) {
+	ref := BatchReference{Type: entityType, Id: id}
+	if in.seen[ref] {
+		return
+	}
+	in.seen[ref] = true
+	in.included = append(in.included, entity)
+}
+
+func (in *includer) item(id int) (*mapping.MappedMultilangItemUnity, error) {
+	raw, err := in.txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "all_items"), "id", id)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	return raw.(*mapping.MappedMultilangItemUnity), nil
+}
+
+func (in *includer) followItem(item *mapping.MappedMultilangItemUnity, tree includeTree) error {
+	for _, relation := range tree.relations() {
+		switch relation {
+		case "parent_set":
+			if !item.HasParentSet {
+				continue
+			}
+			raw, err := in.txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "sets"), "id", item.ParentSet.Id)
+			if err != nil {
+				return err
+			}
+			if raw == nil {
+				continue
+			}
+			set := raw.(*mapping.MappedMultilangSetUnity)
+			in.include(includeTypeSets, set.AnkamaId, set)
+			if err = in.followSet(set, tree[relation]); err != nil {
+				return err
+			}
+		case "recipe.item":
+			recipe, exists := GetRecipeIfExists(item.AnkamaId, in.txn)
+			if !exists {
+				continue
+			}
+			for _, entry := range recipe.Entries {
+				ingredient, err := in.item(entry.ItemId)
+				if err != nil {
+					return err
+				}
+				if ingredient == nil {
+					continue
+				}
+				in.include(includeTypeItems, ingredient.AnkamaId, ingredient)
+				if err = in.followItem(ingredient, tree[relation]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (in *includer) followSet(set *mapping.MappedMultilangSetUnity, tree includeTree) error {
+	if _, ok := tree["equipment"]; !ok {
+		return nil
+	}
+	for _, itemId := range set.ItemIds {
+		item, err := in.item(itemId)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			continue
+		}
+		in.include(includeTypeItems, item.AnkamaId, item)
+		if err = in.followItem(item, tree["equipment"]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render renders the included entities like their single endpoints.
+func (in *includer) render(r *http.Request) []APIIncludedEntity {
+	included := make([]APIIncludedEntity, len(in.included))
+	for i, entity := range in.included {
+		switch entity := entity.(type) {
+		case *mapping.MappedMultilangItemUnity:
+			included[i] = APIIncludedEntity{
+				Type: includeTypeItems,
+				Id:   entity.AnkamaId,
+				Data: utils.RenderLocalized(r, func(lang string) any {
+					return RenderSingleItem(entity, lang, in.txn)
+				}),
+			}
+		case *mapping.MappedMultilangSetUnity:
+			included[i] = APIIncludedEntity{
+				Type: includeTypeSets,
+				Id:   entity.AnkamaId,
+				Data: utils.RenderLocalized(r, func(lang string) any {
+					return RenderSet(entity, lang)
+				}),
+			}
+		}
+	}
+	return included
+}
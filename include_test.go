@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dofusdude/doduapi/database"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+)
+
+func TestParseInclude(t *testing.T) {
+	tree, err := parseInclude("parent_set, recipe.item.parent_set,parent_set.equipment", includeTypeItems)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 2 || len(tree["parent_set"]) != 1 || tree["parent_set"]["equipment"] == nil || tree["recipe.item"]["parent_set"] == nil {
+		t.Error("Expected the merged include tree, got ", tree)
+	}
+
+	invalid := []struct {
+		param      string
+		entityType string
+	}{
+		{"equipment", includeTypeItems},
+		{"recipe", includeTypeItems},
+		{"parent_set", includeTypeSets},
+		{"recipe.item.recipe.item.recipe.item.recipe.item", includeTypeItems},
+		{",", includeTypeItems},
+	}
+	for _, c := range invalid {
+		if _, err := parseInclude(c.param, c.entityType); err == nil {
+			t.Error("Expected error for ", c.param, " on ", c.entityType)
+		}
+	}
+}
+
+func TestIncluderDeduplicates(t *testing.T) {
+	db, err := memdb.NewMemDB(GetMemDBSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	version := utils.CurrentRedBlueVersionStr(database.Version.MemDb)
+	txn := db.Txn(true)
+	hat := &mapping.MappedMultilangItemUnity{AnkamaId: 1, HasParentSet: true, ParentSet: mapping.MappedMultilangSetReverseLink{Id: 10}}
+	cape := &mapping.MappedMultilangItemUnity{AnkamaId: 2, HasParentSet: true, ParentSet: mapping.MappedMultilangSetReverseLink{Id: 10}}
+	wool := &mapping.MappedMultilangItemUnity{AnkamaId: 3}
+	for _, item := range []*mapping.MappedMultilangItemUnity{hat, cape, wool} {
+		if err = txn.Insert(fmt.Sprintf("%s-all_items", version), item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = txn.Insert(fmt.Sprintf("%s-sets", version), &mapping.MappedMultilangSetUnity{AnkamaId: 10, ItemIds: []int{1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, recipe := range []*mapping.MappedMultilangRecipe{
+		{ResultId: 1, Entries: []mapping.MappedMultilangRecipeEntry{{ItemId: 3, Quantity: 2}}},
+		{ResultId: 2, Entries: []mapping.MappedMultilangRecipeEntry{{ItemId: 3, Quantity: 1}}},
+	} {
+		if err = txn.Insert(fmt.Sprintf("%s-recipes", version), recipe); err != nil {
+			t.Fatal(err)
+		}
+	}
+	txn.Commit()
+
+	tree, err := parseInclude("parent_set.equipment,recipe.item", includeTypeItems)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	included := newIncluder(tree, db.Txn(false))
+	if err = included.addItems(hat); err != nil {
+		t.Fatal(err)
+	}
+
+	var refs []string
+	for _, entity := range included.included {
+		switch entity := entity.(type) {
+		case *mapping.MappedMultilangItemUnity:
+			refs = append(refs, fmt.Sprintf("items/%d", entity.AnkamaId))
+		case *mapping.MappedMultilangSetUnity:
+			refs = append(refs, fmt.Sprintf("sets/%d", entity.AnkamaId))
+		}
+	}
+	if fmt.Sprint(refs) != "[sets/10 items/2 items/3]" {
+		t.Error("Expected the set, its other item and the ingredient once, got ", refs)
+	}
+
+	var none *includer
+	if none.addItems(hat) != nil || wrapResponse(nil, "data", none, nil) != "data" {
+		t.Error("Expected a nil includer to leave the response as it is")
+	}
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,8 +15,6 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/hashicorp/go-memdb"
 	meilisearch "github.com/meilisearch/meilisearch-go"
-	g "github.com/zyedidia/generic"
-	"github.com/zyedidia/generic/set"
 
 	"github.com/dofusdude/doduapi/config"
 	"github.com/dofusdude/doduapi/database"
@@ -31,14 +31,22 @@ type SearchType struct {
 	NameId string `json:"name_id"` // old "type_id"
 }
 
+// SearchWeaponStats are the weapon fields the weapon search filters on.
+type SearchWeaponStats struct {
+	ApCost   int `json:"ap_cost"`
+	MinRange int `json:"min_range"`
+	Range    int `json:"range"`
+}
+
 type SearchIndexedItem struct {
-	Id          int             `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	SuperType   SearchStuffType `json:"super_type"`
-	Type        SearchType      `json:"type"`
-	Level       int             `json:"level"`
-	StuffType   SearchStuffType `json:"stuff_type"`
+	Id          int                `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	SuperType   SearchStuffType    `json:"super_type"`
+	Type        SearchType         `json:"type"`
+	Level       int                `json:"level"`
+	StuffType   SearchStuffType    `json:"stuff_type"`
+	Weapon      *SearchWeaponStats `json:"weapon,omitempty"`
 }
 
 type SearchIndexedMount struct {
@@ -62,11 +70,6 @@ type EffectConditionDbEntry struct {
 	Name string
 }
 
-type ItemTypeId struct {
-	Id     int
-	EnName string
-}
-
 func IndexApiData(version *database.VersionT) (*memdb.MemDB, map[string]database.SearchIndexes) {
 	var items []mapping.MappedMultilangItemUnity
 	var sets []mapping.MappedMultilangSetUnity
@@ -324,6 +327,22 @@ func GetMemDBSchema() *memdb.DBSchema {
 			// Maybe add red/blue staging here.
 			"item-type-ids": {
 				Name: "item-type-ids",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "ID"},
+					},
+					"ankama_id": {
+						Name:    "ankama_id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaID"},
+					},
+				},
+			},
+			// Maybe add red/blue staging here.
+			"effects": {
+				Name: "effects",
 				Indexes: map[string]*memdb.IndexSchema{
 					"id": {
 						Name:    "id",
@@ -359,6 +378,26 @@ type AlmanaxBonusListingMeili struct {
 	Name string `json:"name"` // translated text
 }
 
+// gameLanguages returns the languages the item names are translated to. A language added to the game data gets its
+// search indexes and becomes available in the API with the next update.
+func gameLanguages(items []mapping.MappedMultilangItemUnity) []string {
+	found := make(map[string]bool)
+	for _, item := range items {
+		for lang, name := range item.Name {
+			if name != "" {
+				found[lang] = true
+			}
+		}
+	}
+
+	languages := make([]string, 0, len(found))
+	for lang := range found {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
 func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping.MappedMultilangSetUnity, recipes *[]mapping.MappedMultilangRecipe, version *database.VersionT) (*memdb.MemDB, map[string]database.SearchIndexes) {
 	/*
 		item_category_mapping := hashbidimap.New()
@@ -370,6 +409,9 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 		item_category_Put(5, 764933) // Ausschmückungen
 	*/
 
+	languages := gameLanguages(*items)
+	log.Debug("found languages", "languages", languages)
+
 	multilangSearchIndexes := make(map[string]database.SearchIndexes)
 	var indexTasks []*meilisearch.TaskInfo
 
@@ -379,7 +421,7 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 	// generate all indexes with %version-%lang
 	updateTasks := make([]*meilisearch.TaskInfo, 0)
 
-	for _, lang := range config.Languages {
+	for _, lang := range languages {
 		itemIndexUid := fmt.Sprintf("%s-all_items-%s", utils.NextRedBlueVersionStr(version.Search), lang)
 		setIndexUid := fmt.Sprintf("%s-sets-%s", utils.NextRedBlueVersionStr(version.Search), lang)
 		mountIndexUid := fmt.Sprintf("%s-mounts-%s", utils.NextRedBlueVersionStr(version.Search), lang)
@@ -397,12 +439,24 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 			"super_type.name_id",
 			"type.name_id",
 			"level",
+			"weapon.ap_cost",
+			"weapon.min_range",
+			"weapon.range",
 		})
 		if err != nil {
 			log.Fatal(err)
 		}
 		updateTasks = append(updateTasks, allItemsFilterTask)
 
+		// facets count every type and level, like the in-memory listings do
+		allItemsFacetingTask, err := allItemsIdx.UpdateFaceting(&meilisearch.Faceting{
+			MaxValuesPerFacet: 1000,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		updateTasks = append(updateTasks, allItemsFacetingTask)
+
 		allItemsSearchableTask, err := allItemsIdx.UpdateSearchableAttributes(&[]string{
 			"name",
 			"type.name",
@@ -505,7 +559,8 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 		}
 	}
 
-	itemTypeIds := set.NewHashset(10, g.Equals[string], g.HashString)
+	itemTypes := make(map[int]*ItemTypeDbEntry) // by Ankama type id
+	effects := make(effectCatalogue)
 
 	// all items search
 	for _, item := range *items {
@@ -524,8 +579,15 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 			log.Fatal(err)
 		}
 
-		for _, lang := range config.Languages {
-			enTypeId := strings.ToLower(strings.ReplaceAll(itemCp.Type.Name["en"], " ", "-"))
+		itemType, ok := itemTypes[itemCp.Type.ItemTypeId]
+		if !ok {
+			itemType = newItemTypeEntry(itemCp.Type)
+			itemTypes[itemCp.Type.ItemTypeId] = itemType
+		}
+		itemType.addItem(&itemCp)
+		effects.add(&itemCp, itemType)
+
+		for _, lang := range languages {
 			object := SearchIndexedItem{
 				Name:        itemCp.Name[lang],
 				Id:          itemCp.AnkamaId,
@@ -535,15 +597,20 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 				},
 				Type: SearchType{
 					Name:   strings.ToLower(itemCp.Type.Name[lang]),
-					NameId: enTypeId,
+					NameId: itemType.Slug,
 				},
 				Level: itemCp.Level,
 				StuffType: SearchStuffType{
 					NameId: fmt.Sprintf("items-%s", insertCategoryTable),
 				},
 			}
-
-			itemTypeIds.Put(enTypeId)
+			if isWeapon(&itemCp) {
+				object.Weapon = &SearchWeaponStats{
+					ApCost:   itemCp.ApCost,
+					MinRange: itemCp.MinRange,
+					Range:    itemCp.Range,
+				}
+			}
 
 			itemIndexBatch[lang] = append(itemIndexBatch[lang], object)
 			if len(itemIndexBatch[lang]) >= maxBatchSize {
@@ -558,7 +625,7 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 	}
 
 	// leftover items
-	for _, lang := range config.Languages {
+	for _, lang := range languages {
 		if len(itemIndexBatch[lang]) > 0 {
 			var taskInfo *meilisearch.TaskInfo
 			if taskInfo, err = multilangSearchIndexes[lang].AllItems.AddDocuments(itemIndexBatch[lang], nil); err != nil {
@@ -569,13 +636,15 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 		}
 	}
 
-	for id, itemTypeId := range itemTypeIds.Keys() {
-		if err = txn.Insert("item-type-ids", &ItemTypeId{
-			Id:     id,
-			EnName: itemTypeId,
-		}); err != nil {
-			log.Fatal(err)
-		}
+	itemTypeRepo := database.NewDatabaseRepository(context.Background(), config.DbDir)
+	err = registerItemTypes(itemTypeRepo, txn, itemTypes)
+	itemTypeRepo.Deinit()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = effects.insert(txn); err != nil {
+		log.Fatal(err)
 	}
 
 	// sets
@@ -586,7 +655,7 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 			log.Fatal(err)
 		}
 
-		for _, lang := range config.Languages {
+		for _, lang := range languages {
 			object := SearchIndexedSet{
 				Name:                  setCp.Name[lang],
 				Id:                    setCp.AnkamaId,
@@ -611,7 +680,7 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 	}
 
 	// leftover sets
-	for _, lang := range config.Languages {
+	for _, lang := range languages {
 		if len(setIndexBatch[lang]) > 0 {
 			var taskInfo *meilisearch.TaskInfo
 			if taskInfo, err = multilangSearchIndexes[lang].Sets.AddDocuments(setIndexBatch[lang], nil); err != nil {
@@ -628,7 +697,7 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 			continue
 		}
 		itemCp := item
-		for _, lang := range config.Languages {
+		for _, lang := range languages {
 			object := SearchIndexedMount{
 				Name: itemCp.Name[lang],
 				Id:   itemCp.AnkamaId,
@@ -653,7 +722,7 @@ func GenerateDatabase(items *[]mapping.MappedMultilangItemUnity, sets *[]mapping
 	}
 
 	// leftover mounts
-	for _, lang := range config.Languages {
+	for _, lang := range languages {
 		if len(mountIndexBatch[lang]) > 0 {
 			var taskInfo *meilisearch.TaskInfo
 			if taskInfo, err = multilangSearchIndexes[lang].Mounts.AddDocuments(mountIndexBatch[lang], nil); err != nil {
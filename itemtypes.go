@@ -0,0 +1,156 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+)
+
+// ItemTypeDbEntry is an item type of the registry with the number and level range of its items in the current data.
+type ItemTypeDbEntry struct {
+	database.ItemType
+	ItemCount int
+	MinLevel  int
+	MaxLevel  int
+}
+
+// addItem counts an item of the type.
+func (entry *ItemTypeDbEntry) addItem(item *mapping.MappedMultilangItemUnity) {
+	if entry.ItemCount == 0 || item.Level < entry.MinLevel {
+		entry.MinLevel = item.Level
+	}
+	if entry.ItemCount == 0 || item.Level > entry.MaxLevel {
+		entry.MaxLevel = item.Level
+	}
+	entry.ItemCount++
+}
+
+// itemTypeSlug is the english, lowercase name of an item type with dashes, as used by the type filters.
+func itemTypeSlug(enName string) string {
+	return strings.ToLower(strings.ReplaceAll(enName, " ", "-"))
+}
+
+// newItemTypeEntry describes the type of an item for the registry, without id and count.
+func newItemTypeEntry(itemType mapping.MappedMultilangItemTypeUnity) *ItemTypeDbEntry {
+	return &ItemTypeDbEntry{
+		ItemType: database.ItemType{
+			AnkamaID:    itemType.ItemTypeId,
+			Slug:        itemTypeSlug(itemType.Name["en"]),
+			CategoryId:  itemType.CategoryId,
+			SuperTypeId: itemType.SuperTypeId,
+			Names:       itemType.Name,
+		},
+	}
+}
+
+// persistedItemTypeIds returns the ids of item_types*.json by slug. The file only grows, so its ids seed the registry.
+func persistedItemTypeIds() map[string]int64 {
+	ids := make(map[string]int64)
+	if config.PersistedTypes.Entries == nil {
+		return ids
+	}
+
+	it := config.PersistedTypes.Entries.Iterator()
+	for it.Next() {
+		ids[itemTypeSlug(it.Value().(string))] = int64(it.Key().(int))
+	}
+	return ids
+}
+
+// registerItemTypes gives the item types of the data their registry ids and inserts them into the in-memory db.
+func registerItemTypes(repo *database.Repository, txn *memdb.Txn, entries map[int]*ItemTypeDbEntry) error {
+	itemTypes := make([]database.ItemType, 0, len(entries))
+	for _, entry := range entries {
+		itemTypes = append(itemTypes, entry.ItemType)
+	}
+	sort.Slice(itemTypes, func(i, j int) bool {
+		return itemTypes[i].AnkamaID < itemTypes[j].AnkamaID
+	})
+
+	registered, err := repo.RegisterItemTypes(itemTypes, persistedItemTypeIds())
+	if err != nil {
+		return err
+	}
+
+	for _, itemType := range registered {
+		entry := entries[itemType.AnkamaID]
+		entry.ItemType = itemType
+		if err = txn.Insert("item-type-ids", entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// APIItemType is an entry of the item type registry.
+type APIItemType struct {
+	Id          int64  `json:"id"`
+	AnkamaId    int    `json:"ankama_id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name" localized:"true"`
+	Category    string `json:"category"`
+	SuperTypeId int    `json:"super_type_id"`
+	ItemCount   int    `json:"item_count"`
+}
+
+func RenderItemType(itemType *ItemTypeDbEntry, lang string) APIItemType {
+	return APIItemType{
+		Id:          itemType.ID,
+		AnkamaId:    itemType.AnkamaID,
+		Slug:        itemType.Slug,
+		Name:        itemType.Names[lang],
+		Category:    utils.CategoryIdApiMapping(itemType.CategoryId),
+		SuperTypeId: itemType.SuperTypeId,
+		ItemCount:   itemType.ItemCount,
+	}
+}
+
+// itemTypeEntries returns the item types of the current data, ordered by id.
+func itemTypeEntries(txn *memdb.Txn) ([]*ItemTypeDbEntry, error) {
+	it, err := txn.Get("item-type-ids", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var itemTypes []*ItemTypeDbEntry
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		itemTypes = append(itemTypes, obj.(*ItemTypeDbEntry))
+	}
+	return itemTypes, nil
+}
+
+func ListItemTypes(w http.ResponseWriter, r *http.Request) {
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	itemTypes, err := itemTypeEntries(txn)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	if len(itemTypes) == 0 {
+		e.WriteNotFoundResponse(w, "No item types found.")
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		rendered := make([]APIItemType, len(itemTypes))
+		for i, itemType := range itemTypes {
+			rendered[i] = RenderItemType(itemType, lang)
+		}
+		return rendered
+	}))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
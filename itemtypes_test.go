@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/emirpasic/gods/maps/treebidimap"
+	gutils "github.com/emirpasic/gods/utils"
+	"github.com/hashicorp/go-memdb"
+)
+
+func TestItemTypeRegistryIdsAreStable(t *testing.T) {
+	repo := database.NewDatabaseRepository(context.Background(), t.TempDir())
+	defer repo.Deinit()
+	applyMigrations(t, repo, "001_init_schema", "002_alm_xp", "004_translations", "005_item_types")
+
+	persisted := utils.PersistentStringKeysMap{Entries: treebidimap.NewWith(gutils.IntComparator, gutils.StringComparator)}
+	for _, name := range []string{"Amulet", "Ring", "Bow"} {
+		persisted.Entries.Put(persisted.NextId, name)
+		persisted.NextId++
+	}
+	previous := config.PersistedTypes
+	config.PersistedTypes = persisted
+	defer func() { config.PersistedTypes = previous }()
+
+	register := func(types ...mapping.MappedMultilangItemTypeUnity) map[int]int64 {
+		db, err := memdb.NewMemDB(GetMemDBSchema())
+		if err != nil {
+			t.Fatal(err)
+		}
+		txn := db.Txn(true)
+		entries := make(map[int]*ItemTypeDbEntry)
+		for _, itemType := range types {
+			entries[itemType.ItemTypeId] = newItemTypeEntry(itemType)
+		}
+		if err := registerItemTypes(repo, txn, entries); err != nil {
+			t.Fatal(err)
+		}
+		txn.Commit()
+
+		ids := make(map[int]int64)
+		it, _ := db.Txn(false).Get("item-type-ids", "id")
+		for obj := it.Next(); obj != nil; obj = it.Next() {
+			ids[obj.(*ItemTypeDbEntry).AnkamaID] = obj.(*ItemTypeDbEntry).ID
+		}
+		return ids
+	}
+
+	hat := mapping.MappedMultilangItemTypeUnity{ItemTypeId: 16, Name: map[string]string{"en": "Hat", "fr": "Chapeau"}}
+	ring := mapping.MappedMultilangItemTypeUnity{ItemTypeId: 9, Name: map[string]string{"en": "Ring"}}
+	amulet := mapping.MappedMultilangItemTypeUnity{ItemTypeId: 1, Name: map[string]string{"en": "Amulet"}}
+	ids := register(hat, ring, amulet)
+	if ids[1] != 0 || ids[9] != 1 || ids[16] != 3 {
+		t.Error("Expected the ids of item_types.json and new ids after them, got ", ids)
+	}
+
+	// the order of the data and renames do not change the ids
+	hat.Name = map[string]string{"en": "Headgear", "fr": "Chapeau", "de": "Hut"}
+	cape := mapping.MappedMultilangItemTypeUnity{ItemTypeId: 17, Name: map[string]string{"en": "Cape"}}
+	ids = register(cape, hat)
+	if ids[16] != 3 || ids[17] != 4 || len(ids) != 2 {
+		t.Error("Expected the same ids after an update, got ", ids)
+	}
+
+	itemTypes, err := repo.GetItemTypes()
+	if err != nil || len(itemTypes) != 4 {
+		t.Fatal("Expected all registered types, got ", itemTypes, err)
+	}
+	if itemTypes[2].Slug != "headgear" || itemTypes[2].Names["de"] != "Hut" || itemTypes[2].Names["fr"] != "Chapeau" {
+		t.Error("Expected the updated slug and names, got ", itemTypes[2])
+	}
+}
+
+func TestRegisterItemTypesRollsBack(t *testing.T) {
+	repo := database.NewDatabaseRepository(context.Background(), t.TempDir())
+	defer repo.Deinit()
+	applyMigrations(t, repo, "001_init_schema", "002_alm_xp", "005_item_types")
+
+	// without the translations table the names fail after the type itself was inserted
+	_, err := repo.RegisterItemTypes([]database.ItemType{
+		{AnkamaID: 1, Slug: "amulet", Names: map[string]string{"en": "Amulet"}},
+	}, nil)
+	if err == nil {
+		t.Fatal("Expected an error without the translations table")
+	}
+
+	var count int
+	if err := repo.Db.QueryRow(`SELECT COUNT(*) FROM item_types`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Error("Expected no registered item types after the failed registration, got ", count)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/go-chi/chi/v5"
+)
+
+func applyMigrations(t *testing.T, repo *database.Repository, names ...string) {
+	for _, name := range names {
+		migration, err := os.ReadFile("migrations/" + name + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.Db.Exec(string(migration)); err != nil {
+			t.Fatal(name, ": ", err)
+		}
+	}
+}
+
+func TestGameLanguages(t *testing.T) {
+	items := []mapping.MappedMultilangItemUnity{
+		{Name: map[string]string{"en": "Hat", "fr": "Chapeau"}},
+		{Name: map[string]string{"en": "Cape", "it": "Mantello", "de": ""}},
+	}
+
+	if languages := gameLanguages(items); !slices.Equal(languages, []string{"en", "fr", "it"}) {
+		t.Error("Expected the translated languages of all items, got ", languages)
+	}
+}
+
+func TestAlmanaxTranslationsMigration(t *testing.T) {
+	repo := database.NewDatabaseRepository(context.Background(), t.TempDir())
+	defer repo.Deinit()
+
+	applyMigrations(t, repo, "001_init_schema", "002_alm_xp")
+	_, err := repo.Db.Exec(`
+		INSERT INTO bonus_types (id, name_id, name_en, name_fr) VALUES (1, 'harvest', 'Harvest', 'Récolte');
+		INSERT INTO bonus (id, bonus_type_id, description_en, description_fr) VALUES (1, 1, 'More wheat', 'Plus de blé');
+		INSERT INTO tribute (id, item_name_en, item_name_fr, item_ankama_id, item_category_id, item_doduapi_uri, quantity)
+		VALUES (1, 'Wheat', 'Blé', 289, 2, 'dofus3/v1/${lang}/items/resources/289', 5);
+		INSERT INTO almanax (bonus_id, tribute_id, date, reward_kamas) VALUES (1, 1, '2026-01-01', 100);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyMigrations(t, repo, "004_translations")
+
+	mapped, err := repo.GetAlmanaxByDateRange("2026-01-01", "2026-01-01")
+	if err != nil || len(mapped) != 1 {
+		t.Fatal("Expected the migrated day, got ", mapped, err)
+	}
+	if mapped[0].Bonus.Descriptions["fr"] != "Plus de blé" || mapped[0].BonusType.Names["en"] != "Harvest" || mapped[0].Tribute.ItemNames["fr"] != "Blé" {
+		t.Error("Expected the texts to be moved to translations, got ", mapped[0])
+	}
+
+	// a language added to the game data fills in the existing bonus and shows up without code changes
+	_, err = repo.CreateOrUpdate("2026-01-02", &mapping.MappedMultilangNPCAlmanaxUnity{
+		Offering: mapping.MappedAlmanaxOffering{
+			ItemId:         289,
+			ItemName:       map[string]string{"en": "Wheat", "fr": "Blé", "it": "Grano"},
+			Quantity:       5,
+			ItemCategoryId: 2,
+		},
+		Bonus:       map[string]string{"en": "More wheat", "fr": "Plus de blé", "it": "Più grano"},
+		BonusType:   map[string]string{"en": "Harvest", "fr": "Récolte", "it": "Raccolto"},
+		RewardKamas: 120,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapped, err = repo.GetAlmanaxByDateRange("2026-01-01", "2026-01-02")
+	if err != nil || len(mapped) != 2 {
+		t.Fatal("Expected two days, got ", mapped, err)
+	}
+	if mapped[1].Bonus.ID != 1 || mapped[1].Bonus.Descriptions["it"] != "Più grano" || mapped[0].BonusType.Names["it"] != "Raccolto" {
+		t.Error("Expected the existing bonus to get the new language, got ", mapped[1])
+	}
+
+	languages, err := repo.GetAlmanaxLanguages()
+	if err != nil || !slices.Equal(languages, []string{"en", "fr", "it"}) {
+		t.Error("Expected the languages of the stored texts, got ", languages, err)
+	}
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	config.SetLanguages([]string{"de", "en", "es", "fr", "pt"})
+	defer config.SetLanguages(nil)
+
+	router := chi.NewRouter()
+	router.With(negotiateLanguage).Get("/sets", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Context().Value("lang").(string)))
+	})
+
+	cases := map[string]string{
+		"":                                  "en",
+		"pt-BR,pt;q=0.9,en;q=0.8":           "pt",
+		"it, fr;q=0.5, de;q=0.7":            "de",
+		"es;q=0, fr;q=0.1":                  "fr",
+		"ja":                                "en",
+		"*":                                 "en",
+		"FR-ca;q=0.4, en-US;q=0.2, *;q=0.1": "fr",
+	}
+	for acceptLanguage, expected := range cases {
+		r := httptest.NewRequest("GET", "/sets", nil)
+		r.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if w.Body.String() != expected || w.Header().Get("Content-Language") != expected {
+			t.Error("Expected ", expected, " for ", acceptLanguage, ", got ", w.Body.String(), w.Header().Get("Content-Language"))
+		}
+		if w.Header().Get("Vary") != "Accept-Language" {
+			t.Error("Expected Vary: Accept-Language, got ", w.Header().Values("Vary"))
+		}
+	}
+}
+
+func TestTranslationFallback(t *testing.T) {
+	config.SetLanguages([]string{"de", "en", "es", "fr", "pt"})
+	defer config.SetLanguages(nil)
+
+	var err error
+	config.TranslationFallback, err = config.ParseTranslationFallback("pt:es:en, fr")
+	defer func() { config.TranslationFallback = nil }()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.ParseTranslationFallback("pt:es,pt:en"); err == nil {
+		t.Error("Expected an error for two chains of the same language")
+	}
+
+	names := map[string]string{"en": "Hat", "es": "Sombrero"}
+	descriptions := map[string]string{"en": "A hat."}
+	render := func(lang string) any {
+		return APIPageItem{
+			Items: []APIListItem{
+				{Id: 1, Name: names[lang], Type: ApiType{Id: 16, Name: "Type " + lang}, Level: 10},
+				{Id: 2, Name: "Cape " + lang, Type: ApiType{Id: 17, Name: descriptions[lang]}, Level: 20},
+			},
+		}
+	}
+
+	router := chi.NewRouter()
+	router.With(languageChecker).Get("/{lang}/items", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(utils.RenderLocalized(r, render))
+	})
+	request := func(url string) string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return strings.TrimSpace(w.Body.String())
+	}
+
+	expected := `{"_links":{"first":null,"last":null,"next":null,"prev":null},"items":[` +
+		`{"_fallback":{"name":"es"},"ankama_id":1,"image_urls":{"icon":""},"level":10,"name":"Sombrero","type":{"id":16,"name":"Type pt"}},` +
+		`{"ankama_id":2,"name":"Cape pt","type":{"_fallback":{"name":"en"},"id":17,"name":"A hat."},"image_urls":{"icon":""},"level":20}]}`
+	var expectedJson, got any
+	_ = json.Unmarshal([]byte(expected), &expectedJson)
+	if err := json.Unmarshal([]byte(request("/pt/items")), &got); err != nil || !reflect.DeepEqual(got, expectedJson) {
+		t.Error("Expected ", expected, ", got ", request("/pt/items"))
+	}
+
+	if body := request("/fr/items"); strings.Contains(body, "_fallback") {
+		t.Error("Expected no fallback for a language without chain, got ", body)
+	}
+	if body := request("/en/items"); strings.Contains(body, "_fallback") || !strings.HasPrefix(body, `{"_links":{"first":null,"prev":null`) {
+		t.Error("Expected the untouched render without missing translations, got ", body)
+	}
+	if body := request("/all/items?langs=pt,en"); strings.Contains(body, "_fallback") {
+		t.Error("Expected no fallback in the all-languages mode, got ", body)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestLanguageCheckerAllLanguages(t *testing.T) {
+	config.SetLanguages([]string{"pt", "de", "en", "es", "fr"})
+	defer config.SetLanguages(nil)
+
+	router := chi.NewRouter()
+	router.With(languageChecker).Get("/{lang}/sets", func(w http.ResponseWriter, r *http.Request) {
+		langs, localized := utils.RequestLanguages(r)
+		json.NewEncoder(w).Encode(map[string]any{"lang": r.Context().Value("lang"), "langs": langs, "localized": localized})
+	})
+
+	request := func(url string) (int, string) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w.Code, strings.TrimSpace(w.Body.String())
+	}
+
+	cases := map[string]string{
+		"/fr/sets":              `{"lang":"fr","langs":["fr"],"localized":false}`,
+		"/all/sets":             `{"lang":"en","langs":["de","en","es","fr","pt"],"localized":true}`,
+		"/all/sets?langs=fr,de": `{"lang":"fr","langs":["fr","de"],"localized":true}`,
+		"/de/sets?langs=en,en":  `{"lang":"de","langs":["en"],"localized":true}`,
+	}
+	for url, expected := range cases {
+		if code, body := request(url); code != http.StatusOK || body != expected {
+			t.Error("Expected ", expected, " for ", url, ", got ", code, body)
+		}
+	}
+
+	if code, _ := request("/all/sets?langs=en,xx"); code != http.StatusBadRequest {
+		t.Error("Expected 400 for an unknown language in langs, got ", code)
+	}
+	if code, _ := request("/xx/sets"); code != http.StatusBadRequest {
+		t.Error("Expected 400 for an unknown language, got ", code)
+	}
+}
+
+func TestRenderLocalized(t *testing.T) {
+	names := map[string]string{"en": "Hat", "fr": "Chapeau"}
+	render := func(lang string) any {
+		return APIPageItem{
+			Items: []APIListItem{{
+				Id:    1,
+				Name:  names[lang],
+				Type:  ApiType{Id: 16, Name: names[lang] + "s"},
+				Level: 10,
+				Effects: []ApiEffect{
+					{MinInt: 1, MaxInt: 2, Type: ApiEffectType{Id: 5, Name: "Vitality " + lang}, Formatted: "1 to 2 " + lang},
+				},
+			}},
+		}
+	}
+
+	r := httptest.NewRequest("GET", "/all/items/equipment", nil)
+	r = r.WithContext(context.WithValue(context.WithValue(r.Context(), "lang", "en"), "langs", []string{"en", "fr"}))
+
+	encoded, err := json.Marshal(utils.RenderLocalized(r, render))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"_links":{"first":null,"last":null,"next":null,"prev":null},"items":[{"ankama_id":1,"effects":[{"formatted":{"en":"1 to 2 en","fr":"1 to 2 fr"},"ignore_int_max":false,` +
+		`"ignore_int_min":false,"int_maximum":2,"int_minimum":1,"type":{"id":5,"is_active":false,"is_meta":false,` +
+		`"name":{"en":"Vitality en","fr":"Vitality fr"}}}],"image_urls":{"icon":""},"level":10,` +
+		`"name":{"en":"Hat","fr":"Chapeau"},"type":{"id":16,"name":{"en":"Hats","fr":"Chapeaus"}}}]}`
+	if string(encoded) != expected {
+		t.Error("Expected ", expected, ", got ", string(encoded))
+	}
+
+	w := httptest.NewRecorder()
+	r = r.WithContext(context.WithValue(r.Context(), "format", utils.FormatNdjson))
+	if err := utils.WriteData(w, r, utils.RenderLocalized(r, render)); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); len(lines) != 1 || !strings.HasPrefix(lines[0], `{"ankama_id":1`) {
+		t.Error("Expected one NDJSON line per item, got ", w.Body.String())
+	}
+
+	r = httptest.NewRequest("GET", "/en/items/equipment", nil)
+	r = r.WithContext(context.WithValue(r.Context(), "lang", "fr"))
+	if page := utils.RenderLocalized(r, render).(APIPageItem); page.Items[0].Name != "Chapeau" {
+		t.Error("Expected a plain render in a single language, got ", page)
+	}
+}
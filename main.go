@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,6 +31,7 @@ import (
 	"github.com/stelzo/migrate/v4"
 	"github.com/stelzo/migrate/v4/database/sqlite3"
 	"github.com/stelzo/migrate/v4/source/file"
+	"google.golang.org/grpc"
 )
 
 var (
@@ -38,7 +42,9 @@ var (
 	DoduapiVersionHelp = DoduapiShort + "\n" + DoduapiVersion + "\nhttps://github.com/dofusdude/doduapi"
 	httpDataServer     *http.Server
 	httpMetricsServer  *http.Server
+	grpcDataServer     *grpc.Server
 	UpdateChan         chan utils.GameVersion
+	renderedResponses  *utils.ResponseCache // nil when disabled
 )
 
 var currentWd string
@@ -49,6 +55,8 @@ func ReadEnvs() {
 	viper.SetDefault("API_SCHEME", "http")
 	viper.SetDefault("API_HOSTNAME", "localhost:3000")
 	viper.SetDefault("API_PORT", "3000")
+	viper.SetDefault("GRPC_PORT", "3002")
+	viper.SetDefault("GRPC_ENABLED", "true")
 	viper.SetDefault("MEILI_PORT", "7700")
 	viper.SetDefault("MEILI_MASTER_KEY", "masterKey")
 	viper.SetDefault("MEILI_PROTOCOL", "http")
@@ -61,6 +69,22 @@ func ReadEnvs() {
 	viper.SetDefault("UPDATE_HOOK_TOKEN", "")
 	viper.SetDefault("DOFUS_VERSION", "")
 	viper.SetDefault("LOG_LEVEL", "warn")
+	viper.SetDefault("GRAPHQL_MAX_DEPTH", 10)
+	viper.SetDefault("GRAPHQL_MAX_COMPLEXITY", 5000)
+	viper.SetDefault("CACHE_MAX_AGE_ENCYCLOPEDIA", 3600)
+	viper.SetDefault("CACHE_MAX_AGE_SEARCH", 300)
+	viper.SetDefault("CACHE_MAX_AGE_META", 300)
+	viper.SetDefault("CACHE_MAX_AGE_IMAGES", 86400)
+	viper.SetDefault("COMPRESSION_MIN_SIZE", 1024)
+	viper.SetDefault("RESPONSE_CACHE_MB", 256)
+	viper.SetDefault("RATE_LIMIT_SEARCH", 60)
+	viper.SetDefault("RATE_LIMIT_LIST", 600)
+	viper.SetDefault("RATE_LIMIT_ALL", 10)
+	viper.SetDefault("RATE_LIMIT_ALMANAX", 120)
+	viper.SetDefault("RATE_LIMIT_IP_HEADER", "")
+	viper.SetDefault("RATE_LIMIT_TRUSTED_HOPS", 1)
+	viper.SetDefault("TRANSLATION_FALLBACK", "pt:es:en")
+	viper.SetDefault("DOCS_REDOC_URL", "https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js")
 
 	var err error
 	currentWd, err = os.Getwd()
@@ -81,6 +105,28 @@ func ReadEnvs() {
 
 	config.AlmanaxMaxLookAhead = viper.GetInt("ALMANAX_MAX_LOOKAHEAD_DAYS")
 	config.AlmanaxDefaultLookAhead = viper.GetInt("ALMANAX_DEFAULT_LOOKAHEAD_DAYS")
+	config.GraphqlMaxDepth = viper.GetInt("GRAPHQL_MAX_DEPTH")
+	config.GraphqlMaxComplexity = viper.GetInt("GRAPHQL_MAX_COMPLEXITY")
+	config.CacheMaxAgeEncyclopedia = time.Duration(viper.GetInt("CACHE_MAX_AGE_ENCYCLOPEDIA")) * time.Second
+	config.CacheMaxAgeSearch = time.Duration(viper.GetInt("CACHE_MAX_AGE_SEARCH")) * time.Second
+	config.CacheMaxAgeMeta = time.Duration(viper.GetInt("CACHE_MAX_AGE_META")) * time.Second
+	config.CacheMaxAgeImages = time.Duration(viper.GetInt("CACHE_MAX_AGE_IMAGES")) * time.Second
+	config.CompressionMinSize = viper.GetInt("COMPRESSION_MIN_SIZE")
+	config.ResponseCacheSize = viper.GetInt("RESPONSE_CACHE_MB") << 20
+	if config.ResponseCacheSize > 0 {
+		renderedResponses = utils.NewResponseCache(config.ResponseCacheSize)
+	}
+	config.RateLimitSearch = viper.GetInt("RATE_LIMIT_SEARCH")
+	config.RateLimitList = viper.GetInt("RATE_LIMIT_LIST")
+	config.RateLimitAll = viper.GetInt("RATE_LIMIT_ALL")
+	config.RateLimitAlmanax = viper.GetInt("RATE_LIMIT_ALMANAX")
+	config.RateLimitIpHeader = viper.GetString("RATE_LIMIT_IP_HEADER")
+	config.RateLimitTrustedHops = max(viper.GetInt("RATE_LIMIT_TRUSTED_HOPS"), 1)
+	config.DocsRedocUrl = viper.GetString("DOCS_REDOC_URL")
+	config.TranslationFallback, err = config.ParseTranslationFallback(viper.GetString("TRANSLATION_FALLBACK"))
+	if err != nil {
+		log.Fatal("Invalid TRANSLATION_FALLBACK", "err", err)
+	}
 
 	dofusVersion := viper.GetString("DOFUS_VERSION")
 	if dofusVersion == "" {
@@ -122,6 +168,8 @@ func ReadEnvs() {
 	config.ApiScheme = viper.GetString("API_SCHEME")
 	config.ApiHostName = viper.GetString("API_HOSTNAME")
 	config.ApiPort = viper.GetString("API_PORT")
+	config.GrpcPort = viper.GetString("GRPC_PORT")
+	config.GrpcEnabled = viper.GetBool("GRPC_ENABLED")
 	config.MeiliKey = viper.GetString("MEILI_MASTER_KEY")
 	config.MeiliHost = fmt.Sprintf("%s://%s:%s", viper.GetString("MEILI_PROTOCOL"), viper.GetString("MEILI_HOST"), viper.GetString("MEILI_PORT"))
 	config.PrometheusEnabled = viper.GetBool("PROMETHEUS")
@@ -147,6 +195,10 @@ func AutoUpdate(version *database.VersionT, updateHook chan utils.GameVersion, u
 		nowOldRecipesTable := fmt.Sprintf("%s-recipes", utils.CurrentRedBlueVersionStr(version.MemDb))
 
 		version.MemDb = !version.MemDb // atomic version switch
+		version.NextGeneration(gameVersion.Version)
+		if renderedResponses != nil {
+			renderedResponses.Purge()
+		}
 		log.Info("updated db version")
 
 		delOldTxn := db.Txn(true)
@@ -181,12 +233,14 @@ func AutoUpdate(version *database.VersionT, updateHook chan utils.GameVersion, u
 		nowOldRedBlueVersion := utils.CurrentRedBlueVersionStr(version.Search)
 
 		log.Info("atomic version switch")
+		nowOldLanguages := config.Languages()
+		config.SetLanguages(slices.Collect(maps.Keys(idx)))
 		version.Search = !version.Search
 
 		client := meilisearch.New(config.MeiliHost, meilisearch.WithAPIKey(config.MeiliKey))
 		defer client.Close()
 
-		for _, lang := range config.Languages {
+		for _, lang := range nowOldLanguages {
 			nowOldItemIndexUid := fmt.Sprintf("%s-all_items-%s", nowOldRedBlueVersion, lang)
 			nowOldSetIndexUid := fmt.Sprintf("%s-sets-%s", nowOldRedBlueVersion, lang)
 			nowOldMountIndexUid := fmt.Sprintf("%s-mounts-%s", nowOldRedBlueVersion, lang)
@@ -290,6 +344,33 @@ var (
 		Long:  `Command to upgrade database`,
 		Run:   migrateUp,
 	}
+
+	keysCmd = &cobra.Command{
+		Use:   "keys",
+		Short: "Manage API keys.",
+	}
+
+	keysCreateCmd = &cobra.Command{
+		Use:   "create <owner>",
+		Short: "issue a new API key",
+		Long:  `Command to issue an API key. The key is printed once, only its hash is stored.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   keysCreate,
+	}
+
+	keysListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "list all API keys",
+		Run:   keysList,
+	}
+
+	keysRevokeCmd = &cobra.Command{
+		Use:   "revoke <id or prefix>",
+		Short: "revoke an API key",
+		Long:  `Command to revoke an API key. Running servers reject it after at most a minute.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   keysRevoke,
+	}
 )
 
 func migrateUp(cmd *cobra.Command, args []string) {
@@ -362,6 +443,82 @@ func migrateDown(cmd *cobra.Command, args []string) {
 	}
 }
 
+func keysRepository(cmd *cobra.Command) *database.Repository {
+	dbdir, err := cmd.Flags().GetString("persistent-dir")
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.DbDir = dbdir
+
+	return database.NewDatabaseRepository(context.Background(), dbdir)
+}
+
+func keysCreate(cmd *cobra.Command, args []string) {
+	contact, err := cmd.Flags().GetString("contact")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	limitFactor, err := cmd.Flags().GetFloat64("limit-factor")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if limitFactor <= 0 {
+		log.Fatal("--limit-factor must be greater than 0")
+	}
+
+	key, prefix, err := generateApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repo := keysRepository(cmd)
+	defer repo.Deinit()
+
+	id, err := repo.CreateApiKey(&database.ApiKey{
+		KeyHash:         hashApiKey(key),
+		Prefix:          prefix,
+		Owner:           args[0],
+		Contact:         contact,
+		RateLimitFactor: limitFactor,
+	})
+	if err != nil {
+		log.Fatalf("could not create the key, did you run doduapi migrate up? %v", err)
+	}
+
+	fmt.Printf("Created API key %d for %s. It is not shown again:\n%s\n", id, args[0], key)
+}
+
+func keysList(cmd *cobra.Command, args []string) {
+	repo := keysRepository(cmd)
+	defer repo.Deinit()
+
+	keys, err := repo.GetApiKeys()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("id\tprefix\towner\tcontact\tlimits\tcreated\tstatus")
+	for _, key := range keys {
+		fmt.Println(formatApiKey(key))
+	}
+}
+
+func keysRevoke(cmd *cobra.Command, args []string) {
+	repo := keysRepository(cmd)
+	defer repo.Deinit()
+
+	revoked, err := repo.RevokeApiKey(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !revoked {
+		log.Fatal("no active key with this id or prefix", "key", args[0])
+	}
+
+	fmt.Println("Revoked", args[0])
+}
+
 func main() {
 	rootCmd.PersistentFlags().Bool("headless", false, "Run without a TUI.")
 	rootCmd.PersistentFlags().Bool("version", false, "Print API version.")
@@ -373,6 +530,13 @@ func main() {
 	migrateCmd.AddCommand(migrateUpCmd)
 	rootCmd.AddCommand(migrateCmd)
 
+	keysCreateCmd.Flags().String("contact", "", "How to reach the key holder, for example an email address.")
+	keysCreateCmd.Flags().Float64("limit-factor", 1, "Multiplies the rate limits for this key.")
+	keysCmd.AddCommand(keysCreateCmd)
+	keysCmd.AddCommand(keysListCmd)
+	keysCmd.AddCommand(keysRevokeCmd)
+	rootCmd.AddCommand(keysCmd)
+
 	err := rootCmd.Execute()
 	if err != nil && err.Error() != "" {
 		fmt.Fprintln(os.Stderr, err)
@@ -420,6 +584,9 @@ func rootCommand(ccmd *cobra.Command, args []string) {
 	// populate env vars
 	ReadEnvs()
 
+	apiKeys = newApiKeyStore(database.NewDatabaseRepository(context.Background(), config.DbDir))
+	go apiKeys.flushEvery(apiKeyUsageFlushInterval)
+
 	feedbackChan := make(chan string, 5)
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -468,8 +635,10 @@ func rootCommand(ccmd *cobra.Command, args []string) {
 	}
 	feedbackChan <- "Database"
 	database.Db, database.Indexes = IndexApiData(&database.Version)
+	config.SetLanguages(slices.Collect(maps.Keys(database.Indexes)))
 	database.Version.Search = !database.Version.Search
 	database.Version.MemDb = !database.Version.MemDb
+	database.Version.NextGeneration(config.DofusVersion)
 
 	updateDb := make(chan *memdb.MemDB)
 	updateSearchIndex := make(chan map[string]database.SearchIndexes)
@@ -506,6 +675,20 @@ func rootCommand(ccmd *cobra.Command, args []string) {
 		}
 	}()
 
+	if config.GrpcEnabled {
+		grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%s", config.GrpcPort))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		grpcDataServer = NewGrpcServer()
+		go func() {
+			if err := grpcDataServer.Serve(grpcListener); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
 	go AutoUpdate(&database.Version, UpdateChan, updateDb, updateSearchIndex)
 
 	if !isChannelClosed(feedbackChan) {
@@ -525,9 +708,9 @@ func rootCommand(ccmd *cobra.Command, args []string) {
 	config.CurrentVersion.Release = releaseLog
 
 	if config.PrometheusEnabled {
-		log.Print("Listening...", "port", apiPort, "metrics", apiPort+1, "release", releaseLog)
+		log.Print("Listening...", "port", apiPort, "grpc", config.GrpcPort, "metrics", apiPort+1, "release", releaseLog)
 	} else {
-		log.Print("Listening...", "port", apiPort, "release", releaseLog)
+		log.Print("Listening...", "port", apiPort, "grpc", config.GrpcPort, "release", releaseLog)
 	}
 
 	go func() {
@@ -546,6 +729,12 @@ func rootCommand(ccmd *cobra.Command, args []string) {
 	if err := httpDataServer.Shutdown(ctx); err != nil {
 		log.Fatal(err)
 	}
+	if grpcDataServer != nil {
+		grpcDataServer.GracefulStop()
+	}
+	if err := apiKeys.flush(); err != nil {
+		log.Warn("Could not store API usage.", "err", err)
+	}
 	if config.PrometheusEnabled {
 		if err := httpMetricsServer.Shutdown(ctx); err != nil {
 			log.Fatal(err)
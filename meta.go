@@ -37,8 +37,8 @@ func ListItemTypeIds(w http.ResponseWriter, r *http.Request) {
 
 	var typeIds []string
 	for obj := it.Next(); obj != nil; obj = it.Next() {
-		effectElement := obj.(*ItemTypeId)
-		typeIds = append(typeIds, effectElement.EnName)
+		itemType := obj.(*ItemTypeDbEntry)
+		typeIds = append(typeIds, itemType.Slug)
 	}
 
 	utils.WriteCacheHeader(&w)
@@ -4,17 +4,66 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dofusdude/doduapi/config"
 	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
 	"github.com/go-chi/chi/v5"
 )
 
-func disablePaginate(next http.Handler) http.Handler {
+// streamTimeout replaces the request timeout for the /all listings. They are written for as long as the client reads.
+const streamTimeout = 10 * time.Minute
+
+// requestDeadline cancels the context of a request with context.DeadlineExceeded as cause once its timer fires.
+type requestDeadline struct {
+	timer    *time.Timer
+	extended bool
+}
+
+// extend moves a deadline that did not pass yet. Only the handler goroutine may call it.
+func (deadline *requestDeadline) extend(timeout time.Duration) {
+	if deadline.timer.Stop() {
+		deadline.timer.Reset(timeout)
+		deadline.extended = true
+	}
+}
+
+// requestTimeout works like middleware.Timeout, but the deadline is stored in the context, so streamListing can
+// extend it. Extended requests already sent their status, so they get no 504.
+func requestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithCancelCause(r.Context())
+			deadline := &requestDeadline{timer: time.AfterFunc(timeout, func() {
+				cancel(context.DeadlineExceeded)
+			})}
+			defer func() {
+				deadline.timer.Stop()
+				timedOut := context.Cause(ctx) == context.DeadlineExceeded
+				cancel(nil)
+				if timedOut && !deadline.extended {
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, "deadline", deadline)))
+		})
+	}
+}
+
+// streamListing marks the /all listings. They are written entry by entry instead of being rendered as one page.
+func streamListing(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if deadline, ok := r.Context().Value("deadline").(*requestDeadline); ok {
+			deadline.extend(streamTimeout)
+		}
+
 		ctx := context.WithValue(r.Context(), "pagination", "1,-1")
+		ctx = context.WithValue(ctx, "stream", true)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -50,6 +99,49 @@ func paginate(next http.Handler) http.Handler {
 
 		ctx := context.WithValue(r.Context(), "pagination", fmt.Sprintf("%d,%d", pageNum, pageSize))
 
+		// page[after] switches to cursor pagination, an empty value starts the crawl
+		if r.URL.Query().Has("page[after]") {
+			if pageNumStr != "" {
+				e.WriteInvalidUrlResponse(w, "page[after] cannot be combined with page[number].")
+				return
+			}
+
+			if pageSize <= 0 {
+				e.WriteInvalidUrlResponse(w, "Invalid page size for cursor pagination: "+pageSizeStr)
+				return
+			}
+
+			cursorPagination := &utils.CursorPagination{PageSize: pageSize}
+			if afterStr := r.URL.Query().Get("page[after]"); afterStr != "" {
+				cursor, err := utils.DecodeCursor(afterStr)
+				if err != nil {
+					e.WriteInvalidUrlResponse(w, "Invalid page[after]: "+err.Error())
+					return
+				}
+				cursorPagination.After = &cursor
+			}
+
+			ctx = context.WithValue(ctx, "cursor", cursorPagination)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// negotiateFormat stores the response format for utils.WriteData. An explicit ?format= takes precedence over Accept.
+func negotiateFormat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format, err := utils.NegotiateFormat(r)
+		if err != nil {
+			if r.URL.Query().Get("format") != "" {
+				e.WriteInvalidQueryResponse(w, err.Error())
+			} else {
+				e.WriteNotAcceptableResponse(w, err.Error())
+			}
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "format", format)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -62,19 +154,86 @@ func useCors(next http.Handler) http.Handler {
 	})
 }
 
+// allLanguages is the {lang} of the all-languages mode. Translated fields are returned for every language at once.
+const allLanguages = "all"
+
+// languageChecker sets the language of the request. It is used for filtering, sorting and search. With the all
+// language or the langs query parameter, the responses render every requested language into language maps.
 func languageChecker(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages := config.Languages()
 		lang := strings.ToLower(chi.URLParam(r, "lang"))
-		switch lang {
-		case "en", "fr", "de", "es", "pt":
-			ctx := context.WithValue(r.Context(), "lang", lang)
-			next.ServeHTTP(w, r.WithContext(ctx))
-		default:
+		if len(languages) == 0 || lang != allLanguages && !slices.Contains(languages, lang) {
 			e.WriteInvalidUrlResponse(w, "Invalid language: "+chi.URLParam(r, "lang"))
+			return
+		}
+
+		serveLanguage(w, r, next, lang, languages)
+	})
+}
+
+// negotiateLanguage is the languageChecker of the routes without {lang}. The language comes from Accept-Language
+// and is English if the header accepts none of the available languages.
+func negotiateLanguage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		languages := config.Languages()
+		if len(languages) == 0 {
+			e.WriteServerErrorResponse(w, "No languages loaded yet.")
+			return
 		}
+
+		lang, ok := utils.NegotiateLanguage(r.Header.Get("Accept-Language"), languages)
+		if !ok {
+			lang = languages[0]
+			if slices.Contains(languages, "en") {
+				lang = "en"
+			}
+		}
+
+		serveLanguage(w, r, next, lang, languages)
 	})
 }
 
+// serveLanguage applies the langs query parameter and the translation fallback to the request language and sets
+// Content-Language.
+func serveLanguage(w http.ResponseWriter, r *http.Request, next http.Handler, lang string, languages []string) {
+	var langs []string
+	if langsParam := r.URL.Query().Get("langs"); langsParam != "" {
+		for _, requested := range strings.Split(strings.ToLower(langsParam), ",") {
+			requested = strings.TrimSpace(requested)
+			if !slices.Contains(languages, requested) {
+				e.WriteInvalidQueryResponse(w, fmt.Sprintf("Invalid language %s in langs, use some of %s", requested, strings.Join(languages, ",")))
+				return
+			}
+			if !slices.Contains(langs, requested) {
+				langs = append(langs, requested)
+			}
+		}
+	} else if lang == allLanguages {
+		langs = languages
+	}
+
+	if lang == allLanguages {
+		lang = langs[0]
+		if slices.Contains(langs, "en") {
+			lang = "en"
+		}
+	}
+
+	ctx := context.WithValue(r.Context(), "lang", lang)
+	if langs != nil {
+		ctx = context.WithValue(ctx, "langs", langs)
+		w.Header().Set("Content-Language", strings.Join(langs, ", "))
+	} else {
+		// the all-languages mode shows missing translations as they are, so it has no fallback
+		ctx = context.WithValue(ctx, "fallback", config.FallbackLanguages(lang))
+		w.Header().Set("Content-Language", lang)
+	}
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 func ankamaIdExtractor(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ankamaId, err := strconv.Atoi(chi.URLParam(r, "ankamaId"))
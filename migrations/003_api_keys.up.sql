@@ -0,0 +1,21 @@
+create table api_keys (
+    id integer primary key autoincrement,
+    key_hash text not null,
+    prefix text not null,
+    owner text not null,
+    contact text,
+    rate_limit_factor float not null default 1,
+    created_at datetime default current_timestamp,
+    revoked_at datetime
+);
+
+create unique index idx_api_keys_key_hash on api_keys (key_hash);
+
+-- api_key_id 0 counts anonymous requests
+create table api_key_usage (
+    api_key_id integer not null,
+    day text not null,
+    route text not null,
+    requests integer not null default 0,
+    primary key (api_key_id, day, route)
+);
@@ -0,0 +1,93 @@
+alter table bonus_types
+add column name_en text;
+
+alter table bonus_types
+add column name_fr text;
+
+alter table bonus_types
+add column name_es text;
+
+alter table bonus_types
+add column name_de text;
+
+alter table bonus_types
+add column name_pt text;
+
+alter table bonus
+add column description_en text;
+
+alter table bonus
+add column description_fr text;
+
+alter table bonus
+add column description_es text;
+
+alter table bonus
+add column description_de text;
+
+alter table bonus
+add column description_pt text;
+
+alter table tribute
+add column item_name_en text;
+
+alter table tribute
+add column item_name_fr text;
+
+alter table tribute
+add column item_name_es text;
+
+alter table tribute
+add column item_name_de text;
+
+alter table tribute
+add column item_name_pt text;
+
+update bonus_types
+set name_en = (select value from translations where entity = 'bonus_types' and entity_id = bonus_types.id and field = 'name' and lang = 'en');
+
+update bonus_types
+set name_fr = (select value from translations where entity = 'bonus_types' and entity_id = bonus_types.id and field = 'name' and lang = 'fr');
+
+update bonus_types
+set name_es = (select value from translations where entity = 'bonus_types' and entity_id = bonus_types.id and field = 'name' and lang = 'es');
+
+update bonus_types
+set name_de = (select value from translations where entity = 'bonus_types' and entity_id = bonus_types.id and field = 'name' and lang = 'de');
+
+update bonus_types
+set name_pt = (select value from translations where entity = 'bonus_types' and entity_id = bonus_types.id and field = 'name' and lang = 'pt');
+
+update bonus
+set description_en = (select value from translations where entity = 'bonus' and entity_id = bonus.id and field = 'description' and lang = 'en');
+
+update bonus
+set description_fr = (select value from translations where entity = 'bonus' and entity_id = bonus.id and field = 'description' and lang = 'fr');
+
+update bonus
+set description_es = (select value from translations where entity = 'bonus' and entity_id = bonus.id and field = 'description' and lang = 'es');
+
+update bonus
+set description_de = (select value from translations where entity = 'bonus' and entity_id = bonus.id and field = 'description' and lang = 'de');
+
+update bonus
+set description_pt = (select value from translations where entity = 'bonus' and entity_id = bonus.id and field = 'description' and lang = 'pt');
+
+update tribute
+set item_name_en = (select value from translations where entity = 'tribute' and entity_id = tribute.id and field = 'item_name' and lang = 'en');
+
+update tribute
+set item_name_fr = (select value from translations where entity = 'tribute' and entity_id = tribute.id and field = 'item_name' and lang = 'fr');
+
+update tribute
+set item_name_es = (select value from translations where entity = 'tribute' and entity_id = tribute.id and field = 'item_name' and lang = 'es');
+
+update tribute
+set item_name_de = (select value from translations where entity = 'tribute' and entity_id = tribute.id and field = 'item_name' and lang = 'de');
+
+update tribute
+set item_name_pt = (select value from translations where entity = 'tribute' and entity_id = tribute.id and field = 'item_name' and lang = 'pt');
+
+drop index if exists idx_translations_value;
+
+drop table translations;
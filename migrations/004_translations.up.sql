@@ -0,0 +1,101 @@
+-- texts of bonus_types (name), bonus (description) and tribute (item_name) in every language of the game data
+create table translations (
+    entity text not null,
+    entity_id integer not null,
+    field text not null,
+    lang text not null,
+    value text not null,
+    primary key (entity, entity_id, field, lang)
+);
+
+create index idx_translations_value on translations (entity, field, lang, value);
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus_types', id, 'name', 'en', name_en from bonus_types where name_en is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus_types', id, 'name', 'fr', name_fr from bonus_types where name_fr is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus_types', id, 'name', 'es', name_es from bonus_types where name_es is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus_types', id, 'name', 'de', name_de from bonus_types where name_de is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus_types', id, 'name', 'pt', name_pt from bonus_types where name_pt is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus', id, 'description', 'en', description_en from bonus where description_en is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus', id, 'description', 'fr', description_fr from bonus where description_fr is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus', id, 'description', 'es', description_es from bonus where description_es is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus', id, 'description', 'de', description_de from bonus where description_de is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'bonus', id, 'description', 'pt', description_pt from bonus where description_pt is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'tribute', id, 'item_name', 'en', item_name_en from tribute where item_name_en is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'tribute', id, 'item_name', 'fr', item_name_fr from tribute where item_name_fr is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'tribute', id, 'item_name', 'es', item_name_es from tribute where item_name_es is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'tribute', id, 'item_name', 'de', item_name_de from tribute where item_name_de is not null;
+
+insert into translations (entity, entity_id, field, lang, value)
+select 'tribute', id, 'item_name', 'pt', item_name_pt from tribute where item_name_pt is not null;
+
+alter table bonus_types
+drop column name_en;
+
+alter table bonus_types
+drop column name_fr;
+
+alter table bonus_types
+drop column name_es;
+
+alter table bonus_types
+drop column name_de;
+
+alter table bonus_types
+drop column name_pt;
+
+alter table bonus
+drop column description_en;
+
+alter table bonus
+drop column description_fr;
+
+alter table bonus
+drop column description_es;
+
+alter table bonus
+drop column description_de;
+
+alter table bonus
+drop column description_pt;
+
+alter table tribute
+drop column item_name_en;
+
+alter table tribute
+drop column item_name_fr;
+
+alter table tribute
+drop column item_name_es;
+
+alter table tribute
+drop column item_name_de;
+
+alter table tribute
+drop column item_name_pt;
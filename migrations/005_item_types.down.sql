@@ -0,0 +1,5 @@
+delete from translations where entity = 'item_types';
+
+drop index idx_item_types_ankama_id;
+
+drop table item_types;
@@ -0,0 +1,12 @@
+-- stable ids of the item types, their names are in translations with entity item_types and field name
+create table item_types (
+    id integer primary key,
+    ankama_id integer not null,
+    slug text not null,
+    category_id integer not null,
+    super_type_id integer not null,
+    created_at datetime default current_timestamp,
+    updated_at datetime default current_timestamp
+);
+
+create unique index idx_item_types_ankama_id on item_types (ankama_id);
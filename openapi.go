@@ -0,0 +1,836 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"maps"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dofusdude/doduapi/almanax"
+	"github.com/dofusdude/doduapi/config"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/graphql-go/graphql"
+)
+
+// Routes that are not part of the public API description. The update hook contains its secret token in the path.
+var openapiIgnoredRoutePrefixes = []string{"/img", "/update"}
+
+var openapiPathParamRe = regexp.MustCompile(`{([^}]+)}`)
+
+type openapiParam struct {
+	Name        string
+	Description string
+	Required    bool
+	Schema      map[string]any
+}
+
+// openapiOneOf documents a response or field that holds one of several types.
+type openapiOneOf []any
+
+type openapiOperation struct {
+	Summary     string
+	Description string
+	Tag         string
+	Params      []openapiParam
+	Body        any
+	Response    any
+	ContentType string // defaults to application/json
+
+	// NegotiatedLanguage marks the routes without {lang} that take the language from Accept-Language.
+	NegotiatedLanguage bool
+}
+
+var openapiTypeDescriptions = map[string]string{
+	"ApiAllSearchItem":            "Item specific fields of a global search result, selected with fields[item].",
+	"ApiAllSearchResult":          "A global search result of any search index.",
+	"ApiAllSearchResultType":      "The search index a result comes from.",
+	"ApiCondition":                "A single condition on a characteristic.",
+	"ApiConditionEvaluation":      "The result of evaluating the conditions of an equipment against given characteristics.",
+	"ApiConditionEvaluationNode":  "A node of the evaluated condition tree.",
+	"ApiConditionNode":            "A node of a condition tree. Leaves are operands, inner nodes combine their children with a relation.",
+	"ApiConditionType":            "The characteristic a condition checks, listed in /meta/elements.",
+	"ApiEffect":                   "An effect with its value range and the formatted, translated text.",
+	"ApiEffectType":               "The type of an effect.",
+	"ApiImageUrls":                "Image urls in increasing resolution. Only the icon is always present.",
+	"ApiType":                     "The item type, for example Hat or Sword.",
+	"APIBatch":                    "The resolved batch entries in request order.",
+	"APIBatchEntry":               "One resolved batch reference. data is missing if the entity was not found.",
+	"APIEffectCatalogueEntry":     "An effect of the catalogue. key is a stable name for code, template the text with #1 and #2 for the values. Meta effects have no values.",
+	"APIEffectItemType":           "An item type whose items have the effect, with the lowest and highest observed value.",
+	"APIEquipment":                "An equipment or cosmetic that is not a weapon.",
+	"APIItemType":                 "An item type of the registry. The id is assigned by this API and never changes, ankama_id is the type id of the game.",
+	"APIKeyInfo":                  "The API key the usage belongs to.",
+	"APIKeyUsage":                 "The requests of an API key per day and route.",
+	"APIKeyUsageEntry":            "The requests to one route on one day (UTC).",
+	"APIListItem":                 "An item in a listing. Optional fields are added with fields[item].",
+	"APIListItemType":             "The item category an item belongs to.",
+	"APIListSet":                  "A set in a listing. Optional fields are added with fields[set].",
+	"APIListTypedItem":            "An item in a listing over all categories.",
+	"APIMount":                    "A mount.",
+	"APIMountFamily":              "The family of a mount.",
+	"APIPageItem":                 "A page of items.",
+	"APIPageMount":                "A page of mounts.",
+	"APIPageSet":                  "A page of sets.",
+	"APIPageWeapon":               "A page of weapons.",
+	"APIRange":                    "The cast range of a weapon.",
+	"APIRecipe":                   "One ingredient of a recipe.",
+	"APIResource":                 "A consumable, resource or quest item.",
+	"APISet":                      "A set with its bonuses by the amount of worn items.",
+	"APISetReverseLink":           "The set an item belongs to.",
+	"APITaxonomyCategory":         "An item category with its super types. Counts and level ranges cover all items below.",
+	"APITaxonomyItemType":         "An item type in the taxonomy. is_mount types are served by /mounts, is_weapon ones are weapons and is_cosmetic ones are served by /items/cosmetics.",
+	"APITaxonomySuperType":        "A super type with its item types. Only super types of a single item type and weapons have a name.",
+	"APITypedItem":                "A single item of any category together with its category.",
+	"APIWeapon":                   "A weapon.",
+	"BatchReference":              "A reference to one entity by type and ankama id.",
+	"BatchRequest":                "The entities to resolve in one request.",
+	"ConditionEvaluationRequest":  "Characteristics by element id from /meta/elements.",
+	"GraphqlRequest":              "A GraphQL query with optional operation name and variables.",
+	"almanax.AlmanaxBonusListing": "An almanax bonus type.",
+	"almanax.AlmanaxResponse":     "The almanax of one day.",
+	"almanax.ApiImageUrls":        "Image urls in increasing resolution. Only the icon is always present.",
+	"errmsg.ApiError":             "The body of every error response.",
+	"gqlerrors.FormattedError":    "A GraphQL error.",
+	"graphql.Result":              "The result of a GraphQL query.",
+	"location.SourceLocation":     "A position in the GraphQL query.",
+	"utils.GameVersion":           "The game version the data is extracted from.",
+	"utils.PaginationLinks":       "Links to other pages. Missing pages are null.",
+}
+
+// openapiFieldTypes documents fields declared as any.
+var openapiFieldTypes = map[string]openapiOneOf{
+	"APITypedItem.item":  {APIWeapon{}, APIEquipment{}, APIResource{}},
+	"APIBatchEntry.data": {APIListItem{}, APIListSet{}, APIMount{}},
+}
+
+func stringSchema() map[string]any {
+	return map[string]any{"type": "string"}
+}
+
+func integerSchema() map[string]any {
+	return map[string]any{"type": "integer"}
+}
+
+func booleanSchema() map[string]any {
+	return map[string]any{"type": "boolean"}
+}
+
+func queryParam(name string, description string, schema map[string]any) openapiParam {
+	return openapiParam{Name: name, Description: description, Schema: schema}
+}
+
+func listParam(name string, description string, allowed []string) openapiParam {
+	return queryParam(name, fmt.Sprintf("%s Comma separated, one of %s.", description, strings.Join(allowed, ", ")), stringSchema())
+}
+
+func searchParams() []openapiParam {
+	return []openapiParam{
+		{Name: "query", Description: "The search text.", Required: true, Schema: stringSchema()},
+		queryParam("limit", "Maximum amount of results, at most 100. Defaults to 8.", integerSchema()),
+	}
+}
+
+func pageParams() []openapiParam {
+	return []openapiParam{
+		queryParam("page[number]", "The page to return, starting at 1.", integerSchema()),
+		queryParam("page[size]", "Entries per page, -1 returns everything. Defaults to 16.", integerSchema()),
+		queryParam("page[after]", "Cursor from the next link. An empty value starts cursor pagination. Cannot be combined with page[number].", stringSchema()),
+	}
+}
+
+func sortParam(allowed []string) openapiParam {
+	return listParam("sort", "Sort keys, prefixed with - for descending order. effects.<id>.min and effects.<id>.max sort by an effect.", allowed)
+}
+
+func levelRangeParams(name string) []openapiParam {
+	return []openapiParam{
+		queryParam(fmt.Sprintf("filter[min_%s]", name), "Only entries with at least this level.", integerSchema()),
+		queryParam(fmt.Sprintf("filter[max_%s]", name), "Only entries with at most this level.", integerSchema()),
+	}
+}
+
+func typeFilterParam() openapiParam {
+	return queryParam("filter[type.name_id]", "Comma separated english type names from /meta/items/types. Prefix with - to exclude a type.", stringSchema())
+}
+
+func includeParam(entityType string) []openapiParam {
+	relations := slices.Sorted(maps.Keys(includeRelations[entityType]))
+	description := fmt.Sprintf("Comma separated relations to side-load, one of %s, followed by relations of the related entities up to %d deep, for example recipe.item.parent_set. The response becomes {\"data\": ..., \"included\": [...]} with every related entity once, rendered like its single endpoint. Not available for CSV and NDJSON.", strings.Join(relations, ", "), maxIncludeDepth)
+	return []openapiParam{queryParam("include", description, stringSchema())}
+}
+
+func facetsParam() []openapiParam {
+	description := fmt.Sprintf("Comma separated fields to count the matching items by, one of %s. The response becomes {\"data\": ..., \"facets\": [...]} with the values of each field, their count and translated name. level also has its min and max. Not available for CSV and NDJSON.", strings.Join(itemFacetFields, ", "))
+	return []openapiParam{queryParam("facets", description, stringSchema())}
+}
+
+func cosmeticsFilterParams() []openapiParam {
+	return []openapiParam{
+		queryParam("filter[contains_cosmetics]", "Only sets with or without cosmetics.", booleanSchema()),
+		queryParam("filter[contains_cosmetics_only]", "Only sets with or without equipment.", booleanSchema()),
+	}
+}
+
+func concatParams(groups ...[]openapiParam) []openapiParam {
+	var params []openapiParam
+	for _, group := range groups {
+		params = append(params, group...)
+	}
+	return params
+}
+
+// itemCategoryOperations documents the routes every item category has.
+func itemCategoryOperations(operations map[string]openapiOperation, category string, name string, single any, expandFields []string) {
+	base := "/{lang}/items/" + category
+	listParams := concatParams(
+		[]openapiParam{
+			listParam("fields[item]", "Additional fields.", expandFields),
+			typeFilterParam(),
+			queryParam("filter[ids]", fmt.Sprintf("Comma separated ankama ids, at most %d. Keeps the given order unless sorted.", maxBatchSize), stringSchema()),
+			queryParam("sort[level]", "asc or desc. Use sort instead.", stringSchema()),
+			sortParam(itemSortFields),
+		},
+		levelRangeParams("level"),
+	)
+
+	operations["GET "+base] = openapiOperation{
+		Summary:  "List " + name,
+		Tag:      "Items",
+		Params:   concatParams(listParams, pageParams(), includeParam(includeTypeItems), facetsParam()),
+		Response: APIPageItem{},
+	}
+	operations["GET "+base+"/all"] = openapiOperation{
+		Summary:     "List all " + name,
+		Description: "All entries with all fields in one response, streamed while they are rendered.",
+		Tag:         "Items",
+		Params:      listParams,
+		Response:    APIPageItem{},
+	}
+	operations["GET "+base+"/{ankamaId}"] = openapiOperation{
+		Summary:     "Get a single " + strings.TrimSuffix(name, "s"),
+		Description: "Items of another category are redirected to their category with 301.",
+		Tag:         "Items",
+		Params:      includeParam(includeTypeItems),
+		Response:    single,
+	}
+	operations["GET "+base+"/search"] = openapiOperation{
+		Summary:  "Search " + name,
+		Tag:      "Items",
+		Params:   concatParams(searchParams(), []openapiParam{typeFilterParam()}, levelRangeParams("level"), includeParam(includeTypeItems), facetsParam()),
+		Response: []APIListItem{},
+	}
+}
+
+func openapiOperations() map[string]openapiOperation {
+	operations := map[string]openapiOperation{
+		"GET /graphql": {
+			Summary:     "Run a GraphQL query",
+			Description: "The query is passed as query parameter. Queries are limited in depth and complexity.",
+			Tag:         "GraphQL",
+			Params: []openapiParam{
+				{Name: "query", Description: "The GraphQL query.", Required: true, Schema: stringSchema()},
+				queryParam("operationName", "The operation to run.", stringSchema()),
+				queryParam("variables", "JSON encoded variables.", stringSchema()),
+			},
+			Response: graphql.Result{},
+		},
+		"POST /graphql": {
+			Summary:     "Run a GraphQL query",
+			Description: "Queries are limited in depth and complexity.",
+			Tag:         "GraphQL",
+			Body:        GraphqlRequest{},
+			Response:    graphql.Result{},
+		},
+		"GET /meta/version": {
+			Summary:  "Get the game version",
+			Tag:      "Meta",
+			Response: utils.GameVersion{},
+		},
+		"GET /meta/elements": {
+			Summary:  "List effect and condition elements",
+			Tag:      "Meta",
+			Response: []string{},
+		},
+		"GET /meta/items/types": {
+			Summary:     "List item type slugs",
+			Description: "The slugs of all item types, as used by the type filters. /meta/{lang}/items/types has the full registry.",
+			Tag:         "Meta",
+			Response:    []string{},
+		},
+		"GET /meta/search/types": {
+			Summary:  "List search indices",
+			Tag:      "Meta",
+			Response: []string{},
+		},
+		"GET /meta/openapi.json": {
+			Summary:  "Get this OpenAPI document",
+			Tag:      "Meta",
+			Response: map[string]any{},
+		},
+		"GET /meta/docs": {
+			Summary:     "Browse the API documentation",
+			Tag:         "Meta",
+			Response:    "",
+			ContentType: "text/html",
+		},
+		"GET /meta/usage": {
+			Summary:     "Get the usage of your API key",
+			Description: "Needs an API key. Counts are stored every minute.",
+			Tag:         "Meta",
+			Params: []openapiParam{
+				queryParam("days", "Number of days including today, 1 to 365. Defaults to 30.", integerSchema()),
+			},
+			Response: APIKeyUsage{},
+		},
+		"GET /meta/{lang}/items/types": {
+			Summary:     "List item types",
+			Description: "The item type registry with translated names and the number of items of every type. The ids stay the same across updates.",
+			Tag:         "Meta",
+			Response:    []APIItemType{},
+		},
+		"GET /meta/{lang}/taxonomy": {
+			Summary:     "Get the item taxonomy",
+			Description: "All categories with their super types and item types, with item counts, level ranges and the endpoints the types are served by. It follows the data updates.",
+			Tag:         "Meta",
+			Response:    []APITaxonomyCategory{},
+		},
+		"GET /meta/{lang}/effects": {
+			Summary:     "List effects",
+			Description: "All effects found on items with a machine key, the translated name and template, the typical sign and the item types carrying them with the observed value ranges. The id is the element id of /meta/elements.",
+			Tag:         "Meta",
+			Response:    []APIEffectCatalogueEntry{},
+		},
+		"GET /meta/{lang}/almanax/bonuses": {
+			Summary:  "List almanax bonus types",
+			Tag:      "Almanax",
+			Response: []almanax.AlmanaxBonusListing{},
+		},
+		"GET /meta/{lang}/almanax/bonuses/search": {
+			Summary:  "Search almanax bonus types",
+			Tag:      "Almanax",
+			Params:   searchParams(),
+			Response: []almanax.AlmanaxBonusListing{},
+		},
+		"GET /{lang}/search": {
+			Summary: "Search everything",
+			Tag:     "Search",
+			Params: concatParams(searchParams(), []openapiParam{
+				listParam("filter[search_index]", "Search indices.", searchAllowedIndices),
+				listParam("fields[item]", "Additional fields for items.", searchAllItemAllowedExpandFields),
+				typeFilterParam(),
+			}, facetsParam()),
+			Response: []ApiAllSearchResult{},
+		},
+		"POST /{lang}/batch": {
+			Summary:     "Get many entities at once",
+			Description: fmt.Sprintf("Resolves up to %d references of mixed types.", maxBatchSize),
+			Tag:         "Batch",
+			Params: []openapiParam{
+				listParam("fields[item]", "Additional fields for items.", equipmentAllowedExpandFields),
+				listParam("fields[set]", "Additional fields for sets.", setAllowedExpandFields),
+				listParam("fields[mount]", "Additional fields for mounts.", mountAllowedExpandFields),
+			},
+			Body:     BatchRequest{},
+			Response: APIBatch{},
+		},
+		"GET /{lang}/almanax": {
+			Summary: "Get the almanax of a date range",
+			Tag:     "Almanax",
+			Params: []openapiParam{
+				queryParam("range[from]", "First day as YYYY-MM-DD. Defaults to today.", stringSchema()),
+				queryParam("range[to]", "Last day as YYYY-MM-DD.", stringSchema()),
+				queryParam("range[size]", "Amount of days, combined with either range[from] or range[to].", integerSchema()),
+				queryParam("filter[bonus_type]", "Only days with this bonus type id.", stringSchema()),
+				queryParam("timezone", "The timezone of today. Defaults to Europe/Paris.", stringSchema()),
+				queryParam("level", "Character level between 1 and 200 for reward_xp.", integerSchema()),
+			},
+			Response: []almanax.AlmanaxResponse{},
+		},
+		"GET /{lang}/almanax/{date}": {
+			Summary:  "Get the almanax of a day",
+			Tag:      "Almanax",
+			Params:   []openapiParam{queryParam("level", "Character level between 1 and 200 for reward_xp.", integerSchema())},
+			Response: almanax.AlmanaxResponse{},
+		},
+		"POST /{lang}/items/equipment/{ankamaId}/conditions/evaluate": {
+			Summary:  "Evaluate the conditions of an equipment",
+			Tag:      "Items",
+			Body:     ConditionEvaluationRequest{},
+			Response: ApiConditionEvaluation{},
+		},
+		"GET /{lang}/items/search": {
+			Summary:  "Search all items",
+			Tag:      "Items",
+			Params:   concatParams(searchParams(), []openapiParam{typeFilterParam()}, levelRangeParams("level"), includeParam(includeTypeItems), facetsParam()),
+			Response: []APIListTypedItem{},
+		},
+		"GET /{lang}/items/{ankamaId}": {
+			Summary:  "Get an item of any category",
+			Tag:      "Items",
+			Params:   includeParam(includeTypeItems),
+			Response: APITypedItem{},
+		},
+	}
+
+	itemCategoryOperations(operations, "consumables", "consumables", APIResource{}, itemAllowedExpandFields)
+	itemCategoryOperations(operations, "resources", "resources", APIResource{}, itemAllowedExpandFields)
+	itemCategoryOperations(operations, "equipment", "equipment", openapiOneOf{APIWeapon{}, APIEquipment{}}, equipmentAllowedExpandFields)
+	itemCategoryOperations(operations, "quest", "quest items", APIResource{}, itemAllowedExpandFields)
+	itemCategoryOperations(operations, "cosmetics", "cosmetics", openapiOneOf{APIWeapon{}, APIEquipment{}}, itemAllowedExpandFields)
+
+	weaponFilters := concatParams(
+		[]openapiParam{
+			typeFilterParam(),
+			queryParam("filter[two_handed]", "Only one or two-handed weapons.", booleanSchema()),
+			queryParam("filter[ap_cost]", "Only weapons with this AP cost.", integerSchema()),
+			queryParam("filter[min_range]", "Only weapons that cannot hit closer than this.", integerSchema()),
+			queryParam("filter[max_range]", "Only weapons that cannot hit further than this.", integerSchema()),
+		},
+		levelRangeParams("level"),
+	)
+	weaponListParams := concatParams(weaponFilters, []openapiParam{sortParam(weaponSortFields)})
+	operations["GET /{lang}/items/weapons"] = openapiOperation{Summary: "List weapons", Description: "Equipment weapons with all weapon fields.", Tag: "Items", Params: concatParams(weaponListParams, pageParams(), includeParam(includeTypeItems), facetsParam()), Response: APIPageWeapon{}}
+	operations["GET /{lang}/items/weapons/all"] = openapiOperation{Summary: "List all weapons", Description: "Streamed while they are rendered.", Tag: "Items", Params: weaponListParams, Response: APIPageWeapon{}}
+	operations["GET /{lang}/items/weapons/{ankamaId}"] = openapiOperation{Summary: "Get a single weapon", Description: "Items that are no weapons are redirected to their category with 301.", Tag: "Items", Params: includeParam(includeTypeItems), Response: APIWeapon{}}
+	operations["GET /{lang}/items/weapons/search"] = openapiOperation{Summary: "Search weapons", Tag: "Items", Params: concatParams(searchParams(), weaponFilters, includeParam(includeTypeItems), facetsParam()), Response: []APIWeapon{}}
+
+	mountFilters := []openapiParam{
+		queryParam("filter[family.name]", "Only mounts of this family name.", stringSchema()),
+		queryParam("filter[family.id]", "Only mounts of this family id.", integerSchema()),
+	}
+	mountListParams := concatParams(mountFilters, []openapiParam{
+		listParam("fields[mount]", "Additional fields.", mountAllowedExpandFields),
+		sortParam(mountSortFields),
+	})
+	operations["GET /{lang}/mounts"] = openapiOperation{Summary: "List mounts", Tag: "Mounts", Params: concatParams(mountListParams, pageParams()), Response: APIPageMount{}}
+	operations["GET /{lang}/mounts/all"] = openapiOperation{Summary: "List all mounts", Description: "Streamed while they are rendered.", Tag: "Mounts", Params: mountListParams, Response: APIPageMount{}}
+	operations["GET /{lang}/mounts/{ankamaId}"] = openapiOperation{Summary: "Get a single mount", Tag: "Mounts", Response: APIMount{}}
+	operations["GET /{lang}/mounts/search"] = openapiOperation{Summary: "Search mounts", Tag: "Mounts", Params: concatParams(searchParams(), mountFilters), Response: []APIMount{}}
+
+	setFilters := concatParams(levelRangeParams("highest_equipment_level"), cosmeticsFilterParams())
+	setListParams := concatParams(setFilters, []openapiParam{
+		listParam("fields[set]", "Additional fields.", setAllowedExpandFields),
+		queryParam("sort[level]", "asc or desc. Use sort instead.", stringSchema()),
+		sortParam(setSortFields),
+	})
+	operations["GET /{lang}/sets"] = openapiOperation{Summary: "List sets", Tag: "Sets", Params: concatParams(setListParams, pageParams(), includeParam(includeTypeSets)), Response: APIPageSet{}}
+	operations["GET /{lang}/sets/all"] = openapiOperation{Summary: "List all sets", Description: "Streamed while they are rendered.", Tag: "Sets", Params: setListParams, Response: APIPageSet{}}
+	operations["GET /{lang}/sets/{ankamaId}"] = openapiOperation{Summary: "Get a single set", Tag: "Sets", Params: includeParam(includeTypeSets), Response: APISet{}}
+	operations["GET /{lang}/sets/search"] = openapiOperation{Summary: "Search sets", Tag: "Sets", Params: concatParams(searchParams(), setFilters, includeParam(includeTypeSets)), Response: []APIListSet{}}
+
+	// the routes without {lang} answer like the ones with it
+	negotiated := make(map[string]openapiOperation)
+	for route, operation := range operations {
+		method, routePath, _ := strings.Cut(route, " ")
+		if routePath, ok := strings.CutPrefix(routePath, "/{lang}"); ok {
+			operation.NegotiatedLanguage = true
+			negotiated[method+" "+routePath] = operation
+		}
+	}
+	maps.Copy(operations, negotiated)
+
+	return operations
+}
+
+func openapiPathParam(name string) openapiParam {
+	param := openapiParam{Name: name, Required: true}
+	switch name {
+	case "lang":
+		param.Description = "The language of all texts. all returns every translated text as a map from language to text."
+		param.Schema = map[string]any{"type": "string", "enum": append(slices.Clone(config.Languages()), allLanguages)}
+	case "ankamaId":
+		param.Description = "The ankama id."
+		param.Schema = integerSchema()
+	case "date":
+		param.Description = "The day as YYYY-MM-DD."
+		param.Schema = map[string]any{"type": "string", "format": "date"}
+	default:
+		param.Schema = stringSchema()
+	}
+	return param
+}
+
+// openapiRoutes lists the registered API routes as "METHOD path" relative to the base path.
+func openapiRoutes(router chi.Router) ([]string, error) {
+	basePath := apiBasePath()
+
+	var routes []string
+	err := chi.Walk(router, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		route = strings.TrimPrefix(route, basePath)
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		for _, prefix := range openapiIgnoredRoutePrefixes {
+			if strings.HasPrefix(route, prefix) {
+				return nil
+			}
+		}
+		routes = append(routes, method+" "+route)
+		return nil
+	})
+
+	sort.Strings(routes)
+	return routes, err
+}
+
+type openapiSchemaBuilder struct {
+	components map[string]any
+}
+
+// openapiTypeName names types of this package plainly and qualifies all others with their package name.
+func openapiTypeName(t reflect.Type) string {
+	if t.PkgPath() == reflect.TypeOf(openapiOperation{}).PkgPath() {
+		return t.Name()
+	}
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+func (b *openapiSchemaBuilder) schemaOf(value any) map[string]any {
+	if oneOf, ok := value.(openapiOneOf); ok {
+		schemas := make([]any, 0, len(oneOf))
+		for _, option := range oneOf {
+			schemas = append(schemas, b.schema(reflect.TypeOf(option)))
+		}
+		return map[string]any{"oneOf": schemas}
+	}
+	return b.schema(reflect.TypeOf(value))
+}
+
+func (b *openapiSchemaBuilder) schema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.schema(t.Elem())
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return b.object(t)
+		}
+
+		name := openapiTypeName(t)
+		if _, exists := b.components[name]; !exists {
+			b.components[name] = nil // reserve the name for recursive types
+			object := b.object(t)
+			if description, ok := openapiTypeDescriptions[name]; ok {
+				object["description"] = description
+			}
+			b.components[name] = object
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		schema := map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+		if t.Key().Kind() >= reflect.Int && t.Key().Kind() <= reflect.Uint64 {
+			schema["propertyNames"] = map[string]any{"pattern": "^-?[0-9]+$"}
+		}
+		return schema
+	case reflect.Interface:
+		return map[string]any{}
+	case reflect.String:
+		return stringSchema()
+	case reflect.Bool:
+		return booleanSchema()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return integerSchema()
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (b *openapiSchemaBuilder) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+
+	b.addFields(t, properties, &required)
+
+	object := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		object["required"] = required
+	}
+	return object
+}
+
+func (b *openapiSchemaBuilder) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			b.addFields(field.Type, properties, required)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		var schema map[string]any
+		if oneOf, ok := openapiFieldTypes[t.Name()+"."+name]; ok {
+			schema = b.schemaOf(oneOf)
+		} else {
+			schema = b.schema(field.Type)
+		}
+
+		omitempty := strings.Contains(options, "omitempty")
+		if field.Type.Kind() == reflect.Pointer && !omitempty {
+			if typeName, ok := schema["type"].(string); ok {
+				schema["type"] = []string{typeName, "null"}
+			} else {
+				schema = map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+			}
+		}
+
+		properties[name] = schema
+		if !omitempty {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func (b *openapiSchemaBuilder) content(contentType string, value any) map[string]any {
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	return map[string]any{
+		contentType: map[string]any{"schema": b.schemaOf(value)},
+	}
+}
+
+// BuildOpenapiSpec describes the routes registered in the router. Routes without an entry in openapiOperations are
+// left out, TestOpenapiDescribesAllRoutes makes sure there are none.
+func BuildOpenapiSpec(router chi.Router) (map[string]any, error) {
+	routes, err := openapiRoutes(router)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := openapiOperations()
+	builder := &openapiSchemaBuilder{components: map[string]any{}}
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content":     builder.content("", e.ApiError{}),
+	}
+
+	paths := map[string]any{}
+	tags := map[string]bool{}
+	for _, route := range routes {
+		operation, ok := operations[route]
+		if !ok {
+			continue
+		}
+
+		method, routePath, _ := strings.Cut(route, " ")
+
+		var parameters []any
+		for _, match := range openapiPathParamRe.FindAllStringSubmatch(routePath, -1) {
+			param := openapiPathParam(match[1])
+			parameters = append(parameters, map[string]any{
+				"name":        param.Name,
+				"in":          "path",
+				"required":    true,
+				"description": param.Description,
+				"schema":      param.Schema,
+			})
+		}
+		for _, param := range operation.Params {
+			parameters = append(parameters, map[string]any{
+				"name":        param.Name,
+				"in":          "query",
+				"required":    param.Required,
+				"description": param.Description,
+				"schema":      param.Schema,
+			})
+		}
+
+		if operation.NegotiatedLanguage {
+			parameters = append(parameters, map[string]any{
+				"name":        "Accept-Language",
+				"in":          "header",
+				"required":    false,
+				"description": "The preferred languages. Without an available one, the texts are English. The response has the language in Content-Language.",
+				"schema":      stringSchema(),
+			})
+		}
+
+		content := builder.content(operation.ContentType, operation.Response)
+		if operation.NegotiatedLanguage || strings.HasPrefix(routePath, "/{lang}") || strings.HasPrefix(routePath, "/meta/{lang}") {
+			parameters = append(parameters, map[string]any{
+				"name":        "format",
+				"in":          "query",
+				"required":    false,
+				"description": "The response format, takes precedence over the Accept header. csv and ndjson only contain the list entries.",
+				"schema":      map[string]any{"type": "string", "enum": utils.Formats},
+			})
+			parameters = append(parameters, map[string]any{
+				"name":        "langs",
+				"in":          "query",
+				"required":    false,
+				"description": "Comma separated languages. Every translated text becomes a map from these languages to the text.",
+				"schema":      stringSchema(),
+			})
+			content["application/msgpack"] = content["application/json"]
+			content["application/x-ndjson"] = map[string]any{"schema": stringSchema()}
+			content["text/csv"] = map[string]any{"schema": stringSchema()}
+		}
+
+		spec := map[string]any{
+			"summary":     operation.Summary,
+			"tags":        []string{operation.Tag},
+			"operationId": strings.ToLower(method) + openapiOperationId(routePath),
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     content,
+				},
+				"default": errorResponse,
+			},
+		}
+		if operation.Description != "" {
+			spec["description"] = operation.Description
+		}
+		if len(parameters) > 0 {
+			spec["parameters"] = parameters
+		}
+		if operation.Body != nil {
+			spec["requestBody"] = map[string]any{
+				"required": true,
+				"content":  builder.content("", operation.Body),
+			}
+		}
+
+		pathItem, ok := paths[routePath].(map[string]any)
+		if !ok {
+			pathItem = map[string]any{}
+			paths[routePath] = pathItem
+		}
+		pathItem[strings.ToLower(method)] = spec
+		tags[operation.Tag] = true
+	}
+
+	tagList := make([]string, 0, len(tags))
+	for tag := range tags {
+		tagList = append(tagList, tag)
+	}
+	sort.Strings(tagList)
+	specTags := make([]any, 0, len(tagList))
+	for _, tag := range tagList {
+		specTags = append(specTags, map[string]any{"name": tag})
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       DoduapiShort,
+			"version":     DoduapiVersion,
+			"description": "Filters use filter[...], optional fields fields[...] and pagination page[...] query parameters.",
+			"license":     map[string]any{"name": "GPL-3.0", "identifier": "GPL-3.0-only"},
+		},
+		"servers": []any{
+			map[string]any{"url": fmt.Sprintf("%s://%s%s", config.ApiScheme, config.ApiHostName, apiBasePath())},
+		},
+		"tags":  specTags,
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": builder.components,
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{
+					"type":        "apiKey",
+					"in":          "header",
+					"name":        apiKeyHeader,
+					"description": "Optional. Keys get their own rate limits and usage statistics, the api_key query parameter works as well.",
+				},
+			},
+		},
+		"security": []any{map[string]any{}, map[string]any{"apiKey": []string{}}},
+	}, nil
+}
+
+func openapiOperationId(routePath string) string {
+	var id strings.Builder
+	for _, part := range strings.Split(routePath, "/") {
+		part = strings.Trim(part, "{}")
+		part = strings.NewReplacer(".", "", "_", "").Replace(part)
+		if part == "" {
+			continue
+		}
+		id.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return id.String()
+}
+
+var (
+	openapiSpecMu    sync.Mutex
+	openapiSpecLangs string
+	openapiSpecJson  []byte
+	openapiSpecErr   error
+)
+
+func GetOpenapiSpec(w http.ResponseWriter, r *http.Request) {
+	// the language enum comes from the game data, so an update with other languages needs a new document
+	openapiSpecMu.Lock()
+	langs := strings.Join(config.Languages(), ",")
+	if openapiSpecJson == nil || openapiSpecLangs != langs {
+		var spec map[string]any
+		spec, openapiSpecErr = BuildOpenapiSpec(Router())
+		if openapiSpecErr == nil {
+			openapiSpecJson, openapiSpecErr = json.Marshal(spec)
+		}
+		openapiSpecLangs = langs
+	}
+	spec, err := openapiSpecJson, openapiSpecErr
+	openapiSpecMu.Unlock()
+
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not build OpenAPI document: "+err.Error())
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	_, _ = w.Write(spec)
+}
+
+// openapiDocsPage loads Redoc from config.DocsRedocUrl, a CDN by default. The page only works offline or behind a
+// strict Content-Security-Policy if the bundle is hosted next to the API and the setting points there.
+const openapiDocsPage = `<!doctype html>
+<html>
+<head>
+  <title>%s</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+</head>
+<body>
+  <redoc spec-url="openapi.json"></redoc>
+  <script src="%s"></script>
+</body>
+</html>
+`
+
+func GetOpenapiDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, openapiDocsPage, DoduapiShort, html.EscapeString(config.DocsRedocUrl))
+}
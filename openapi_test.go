@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenapiDescribesAllRoutes(t *testing.T) {
+	routes, err := openapiRoutes(Router())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operations := openapiOperations()
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route] = true
+		if _, ok := operations[route]; !ok {
+			t.Error("Expected an OpenAPI operation for route ", route)
+		}
+	}
+
+	for route := range operations {
+		if !registered[route] {
+			t.Error("Expected a registered route for OpenAPI operation ", route)
+		}
+	}
+}
+
+func TestOpenapiDescribesAllTypes(t *testing.T) {
+	spec, err := BuildOpenapiSpec(Router())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, name := range []string{"APIEquipment", "APIWeapon", "APISet", "almanax.AlmanaxResponse", "errmsg.ApiError"} {
+		if _, ok := schemas[name]; !ok {
+			t.Error("Expected schema ", name)
+		}
+	}
+
+	for name, schema := range schemas {
+		if _, ok := schema.(map[string]any)["description"]; !ok {
+			t.Error("Expected a description in openapiTypeDescriptions for type ", name)
+		}
+	}
+
+	for name := range openapiTypeDescriptions {
+		if _, ok := schemas[name]; !ok {
+			t.Error("Expected description of unused type ", name, " to be removed")
+		}
+	}
+
+	if _, err := json.Marshal(spec); err != nil {
+		t.Error("Expected the spec to encode as JSON, got ", err)
+	}
+}
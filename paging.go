@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+)
+
+func sortKeysString(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// pageBounds selects the page of an already filtered and sorted listing, either by page[number] or by page[after].
+// It writes the error response itself and returns false if the pagination parameters do not fit the listing.
+func pageBounds[T any](w http.ResponseWriter, r *http.Request, entities []T, sortKeys []SortKey, id func(T) int) (int, int, utils.PaginationLinks, bool) {
+	total := len(entities)
+
+	cursorPagination, ok := r.Context().Value("cursor").(*utils.CursorPagination)
+	if !ok || cursorPagination == nil {
+		pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
+		if pagination.ValidatePagination(total) != 0 {
+			e.WriteInvalidQueryResponse(w, "Invalid pagination parameters.")
+			return 0, 0, utils.PaginationLinks{}, false
+		}
+
+		startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+		links, _ := pagination.BuildLinks(*r.URL, total, config.ApiScheme, config.ApiHostName)
+		return startIdx, endIdx, links, true
+	}
+
+	generation := database.Version.Generation.Load()
+	sortStr := sortKeysString(sortKeys)
+
+	startIdx := 0
+	if after := cursorPagination.After; after != nil {
+		if after.Generation != generation {
+			e.WriteStaleCursorResponse(w, "The cursor belongs to an older data version.")
+			return 0, 0, utils.PaginationLinks{}, false
+		}
+
+		if after.Sort != sortStr {
+			e.WriteInvalidQueryResponse(w, fmt.Sprintf("The cursor was issued for sort=%s and cannot be used with sort=%s.", after.Sort, sortStr))
+			return 0, 0, utils.PaginationLinks{}, false
+		}
+
+		startIdx = -1
+		for i, entity := range entities {
+			if id(entity) == after.LastId {
+				startIdx = i + 1
+				break
+			}
+		}
+
+		if startIdx == -1 {
+			e.WriteInvalidQueryResponse(w, "The cursor does not match the current filters.")
+			return 0, 0, utils.PaginationLinks{}, false
+		}
+	}
+
+	endIdx := min(startIdx+cursorPagination.PageSize, total)
+
+	var nextCursor *utils.Cursor
+	if endIdx < total {
+		nextCursor = &utils.Cursor{
+			Generation: generation,
+			Sort:       sortStr,
+			LastId:     id(entities[endIdx-1]),
+		}
+	}
+
+	links := utils.BuildCursorLinks(*r.URL, nextCursor, cursorPagination.PageSize, config.ApiScheme, config.ApiHostName)
+	return startIdx, endIdx, links, true
+}
+
+func isStreamed(r *http.Request) bool {
+	streamed, _ := r.Context().Value("stream").(bool)
+	return streamed
+}
+
+// writeStream streams an already filtered and sorted listing. Errors after the first byte cannot be reported with a
+// status anymore, so they are logged and the connection is aborted, which tells the client that the listing is
+// incomplete. A client that went away is not an error.
+func writeStream(w http.ResponseWriter, r *http.Request, key string, count int, render func(i int) any) {
+	utils.WriteCacheHeader(&w)
+	err := utils.StreamList(w, r, key, count, render)
+	if err == nil || context.Cause(r.Context()) == context.Canceled {
+		return
+	}
+
+	log.Warn("Could not stream listing.", "path", r.URL.Path, "err", err, "cause", context.Cause(r.Context()))
+	panic(http.ErrAbortHandler)
+}
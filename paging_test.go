@@ -1,6 +1,7 @@
 package main
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/dofusdude/doduapi/utils"
@@ -105,3 +106,44 @@ func TestPaginationFirstSite(t *testing.T) {
 		t.Error("Expected 3, got ", endIdx)
 	}
 }
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := utils.Cursor{Generation: 42, Sort: "-level,name", LastId: 1337}
+
+	decoded, err := utils.DecodeCursor(utils.EncodeCursor(cursor))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != cursor {
+		t.Error("Expected ", cursor, ", got ", decoded)
+	}
+
+	if _, err = utils.DecodeCursor("not a cursor"); err == nil {
+		t.Error("Expected error for an invalid cursor")
+	}
+}
+
+func TestCursorLinks(t *testing.T) {
+	mainUrl, _ := url.Parse("/dofus3/v1/en/items/equipment?filter[min_level]=10&page[after]=&page[size]=5")
+	next := utils.Cursor{Generation: 1, LastId: 7}
+
+	links := utils.BuildCursorLinks(*mainUrl, &next, 5, "https", "api.dofusdu.de")
+	if links.Next == nil || links.Prev != nil || links.Last != nil {
+		t.Fatal("Expected only first and next links, got ", links)
+	}
+
+	nextUrl, _ := url.Parse(*links.Next)
+	if nextUrl.Query().Get("filter[min_level]") != "10" {
+		t.Error("Expected filters to be kept, got ", *links.Next)
+	}
+
+	if nextUrl.Query().Get("page[after]") != utils.EncodeCursor(next) {
+		t.Error("Expected next cursor in link, got ", *links.Next)
+	}
+
+	links = utils.BuildCursorLinks(*mainUrl, nil, 5, "https", "api.dofusdu.de")
+	if links.Next != nil {
+		t.Error("Expected no next link on the last page, got ", *links.Next)
+	}
+}
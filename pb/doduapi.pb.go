@@ -0,0 +1,2197 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: doduapi.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ItemCategory int32
+
+const (
+	ItemCategory_ITEM_CATEGORY_UNSPECIFIED ItemCategory = 0
+	ItemCategory_ITEM_CATEGORY_EQUIPMENT   ItemCategory = 1
+	ItemCategory_ITEM_CATEGORY_CONSUMABLES ItemCategory = 2
+	ItemCategory_ITEM_CATEGORY_RESOURCES   ItemCategory = 3
+	ItemCategory_ITEM_CATEGORY_QUEST       ItemCategory = 4
+	ItemCategory_ITEM_CATEGORY_COSMETICS   ItemCategory = 5
+)
+
+// Enum value maps for ItemCategory.
+var (
+	ItemCategory_name = map[int32]string{
+		0: "ITEM_CATEGORY_UNSPECIFIED",
+		1: "ITEM_CATEGORY_EQUIPMENT",
+		2: "ITEM_CATEGORY_CONSUMABLES",
+		3: "ITEM_CATEGORY_RESOURCES",
+		4: "ITEM_CATEGORY_QUEST",
+		5: "ITEM_CATEGORY_COSMETICS",
+	}
+	ItemCategory_value = map[string]int32{
+		"ITEM_CATEGORY_UNSPECIFIED": 0,
+		"ITEM_CATEGORY_EQUIPMENT":   1,
+		"ITEM_CATEGORY_CONSUMABLES": 2,
+		"ITEM_CATEGORY_RESOURCES":   3,
+		"ITEM_CATEGORY_QUEST":       4,
+		"ITEM_CATEGORY_COSMETICS":   5,
+	}
+)
+
+func (x ItemCategory) Enum() *ItemCategory {
+	p := new(ItemCategory)
+	*p = x
+	return p
+}
+
+func (x ItemCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ItemCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_doduapi_proto_enumTypes[0].Descriptor()
+}
+
+func (ItemCategory) Type() protoreflect.EnumType {
+	return &file_doduapi_proto_enumTypes[0]
+}
+
+func (x ItemCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ItemCategory.Descriptor instead.
+func (ItemCategory) EnumDescriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{0}
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_doduapi_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+type GetByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	AnkamaId      int32                  `protobuf:"varint,2,opt,name=ankama_id,json=ankamaId,proto3" json:"ankama_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByIdRequest) Reset() {
+	*x = GetByIdRequest{}
+	mi := &file_doduapi_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIdRequest) ProtoMessage() {}
+
+func (x *GetByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIdRequest.ProtoReflect.Descriptor instead.
+func (*GetByIdRequest) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetByIdRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GetByIdRequest) GetAnkamaId() int32 {
+	if x != nil {
+		return x.AnkamaId
+	}
+	return 0
+}
+
+type GetItemRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Lang     string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	AnkamaId int32                  `protobuf:"varint,2,opt,name=ankama_id,json=ankamaId,proto3" json:"ankama_id,omitempty"`
+	// Unspecified looks the item up in all categories.
+	Category      ItemCategory `protobuf:"varint,3,opt,name=category,proto3,enum=doduapi.v1.ItemCategory" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_doduapi_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetItemRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GetItemRequest) GetAnkamaId() int32 {
+	if x != nil {
+		return x.AnkamaId
+	}
+	return 0
+}
+
+func (x *GetItemRequest) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+type ListItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Category      ItemCategory           `protobuf:"varint,2,opt,name=category,proto3,enum=doduapi.v1.ItemCategory" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsRequest) Reset() {
+	*x = ListItemsRequest{}
+	mi := &file_doduapi_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsRequest) ProtoMessage() {}
+
+func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListItemsRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *ListItemsRequest) GetCategory() ItemCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ItemCategory_ITEM_CATEGORY_UNSPECIFIED
+}
+
+type ImageUrls struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Icon          string                 `protobuf:"bytes,1,opt,name=icon,proto3" json:"icon,omitempty"`
+	Sd            string                 `protobuf:"bytes,2,opt,name=sd,proto3" json:"sd,omitempty"`
+	Hq            string                 `protobuf:"bytes,3,opt,name=hq,proto3" json:"hq,omitempty"`
+	Hd            string                 `protobuf:"bytes,4,opt,name=hd,proto3" json:"hd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageUrls) Reset() {
+	*x = ImageUrls{}
+	mi := &file_doduapi_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageUrls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageUrls) ProtoMessage() {}
+
+func (x *ImageUrls) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageUrls.ProtoReflect.Descriptor instead.
+func (*ImageUrls) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImageUrls) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *ImageUrls) GetSd() string {
+	if x != nil {
+		return x.Sd
+	}
+	return ""
+}
+
+func (x *ImageUrls) GetHq() string {
+	if x != nil {
+		return x.Hq
+	}
+	return ""
+}
+
+func (x *ImageUrls) GetHd() string {
+	if x != nil {
+		return x.Hd
+	}
+	return ""
+}
+
+type EffectType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsMeta        bool                   `protobuf:"varint,3,opt,name=is_meta,json=isMeta,proto3" json:"is_meta,omitempty"`
+	IsActive      bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EffectType) Reset() {
+	*x = EffectType{}
+	mi := &file_doduapi_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EffectType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EffectType) ProtoMessage() {}
+
+func (x *EffectType) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EffectType.ProtoReflect.Descriptor instead.
+func (*EffectType) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EffectType) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EffectType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EffectType) GetIsMeta() bool {
+	if x != nil {
+		return x.IsMeta
+	}
+	return false
+}
+
+func (x *EffectType) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type Effect struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntMinimum    int32                  `protobuf:"varint,1,opt,name=int_minimum,json=intMinimum,proto3" json:"int_minimum,omitempty"`
+	IntMaximum    int32                  `protobuf:"varint,2,opt,name=int_maximum,json=intMaximum,proto3" json:"int_maximum,omitempty"`
+	Type          *EffectType            `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	IgnoreIntMin  bool                   `protobuf:"varint,4,opt,name=ignore_int_min,json=ignoreIntMin,proto3" json:"ignore_int_min,omitempty"`
+	IgnoreIntMax  bool                   `protobuf:"varint,5,opt,name=ignore_int_max,json=ignoreIntMax,proto3" json:"ignore_int_max,omitempty"`
+	Formatted     string                 `protobuf:"bytes,6,opt,name=formatted,proto3" json:"formatted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Effect) Reset() {
+	*x = Effect{}
+	mi := &file_doduapi_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Effect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Effect) ProtoMessage() {}
+
+func (x *Effect) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Effect.ProtoReflect.Descriptor instead.
+func (*Effect) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Effect) GetIntMinimum() int32 {
+	if x != nil {
+		return x.IntMinimum
+	}
+	return 0
+}
+
+func (x *Effect) GetIntMaximum() int32 {
+	if x != nil {
+		return x.IntMaximum
+	}
+	return 0
+}
+
+func (x *Effect) GetType() *EffectType {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *Effect) GetIgnoreIntMin() bool {
+	if x != nil {
+		return x.IgnoreIntMin
+	}
+	return false
+}
+
+func (x *Effect) GetIgnoreIntMax() bool {
+	if x != nil {
+		return x.IgnoreIntMax
+	}
+	return false
+}
+
+func (x *Effect) GetFormatted() string {
+	if x != nil {
+		return x.Formatted
+	}
+	return ""
+}
+
+type ConditionElement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConditionElement) Reset() {
+	*x = ConditionElement{}
+	mi := &file_doduapi_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConditionElement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionElement) ProtoMessage() {}
+
+func (x *ConditionElement) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionElement.ProtoReflect.Descriptor instead.
+func (*ConditionElement) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConditionElement) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConditionElement) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Condition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      string                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	IntValue      int32                  `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3" json:"int_value,omitempty"`
+	Element       *ConditionElement      `protobuf:"bytes,3,opt,name=element,proto3" json:"element,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	mi := &file_doduapi_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Condition) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *Condition) GetIntValue() int32 {
+	if x != nil {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (x *Condition) GetElement() *ConditionElement {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+type ConditionNode struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Condition *Condition             `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	IsOperand bool                   `protobuf:"varint,2,opt,name=is_operand,json=isOperand,proto3" json:"is_operand,omitempty"`
+	// "and" or "or", empty for operands.
+	Relation      string           `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	Children      []*ConditionNode `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConditionNode) Reset() {
+	*x = ConditionNode{}
+	mi := &file_doduapi_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConditionNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionNode) ProtoMessage() {}
+
+func (x *ConditionNode) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionNode.ProtoReflect.Descriptor instead.
+func (*ConditionNode) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConditionNode) GetCondition() *Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *ConditionNode) GetIsOperand() bool {
+	if x != nil {
+		return x.IsOperand
+	}
+	return false
+}
+
+func (x *ConditionNode) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ConditionNode) GetChildren() []*ConditionNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type ItemType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemType) Reset() {
+	*x = ItemType{}
+	mi := &file_doduapi_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemType) ProtoMessage() {}
+
+func (x *ItemType) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemType.ProtoReflect.Descriptor instead.
+func (*ItemType) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ItemType) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ItemType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SetReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReference) Reset() {
+	*x = SetReference{}
+	mi := &file_doduapi_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReference) ProtoMessage() {}
+
+func (x *SetReference) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReference.ProtoReflect.Descriptor instead.
+func (*SetReference) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetReference) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Range struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           int32                  `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max           int32                  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	mi := &file_doduapi_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Range.ProtoReflect.Descriptor instead.
+func (*Range) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Range) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Range) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type WeaponStats struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	CriticalHitProbability int32                  `protobuf:"varint,1,opt,name=critical_hit_probability,json=criticalHitProbability,proto3" json:"critical_hit_probability,omitempty"`
+	CriticalHitBonus       int32                  `protobuf:"varint,2,opt,name=critical_hit_bonus,json=criticalHitBonus,proto3" json:"critical_hit_bonus,omitempty"`
+	MaxCastPerTurn         int32                  `protobuf:"varint,3,opt,name=max_cast_per_turn,json=maxCastPerTurn,proto3" json:"max_cast_per_turn,omitempty"`
+	ApCost                 int32                  `protobuf:"varint,4,opt,name=ap_cost,json=apCost,proto3" json:"ap_cost,omitempty"`
+	Range                  *Range                 `protobuf:"bytes,5,opt,name=range,proto3" json:"range,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WeaponStats) Reset() {
+	*x = WeaponStats{}
+	mi := &file_doduapi_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeaponStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeaponStats) ProtoMessage() {}
+
+func (x *WeaponStats) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeaponStats.ProtoReflect.Descriptor instead.
+func (*WeaponStats) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WeaponStats) GetCriticalHitProbability() int32 {
+	if x != nil {
+		return x.CriticalHitProbability
+	}
+	return 0
+}
+
+func (x *WeaponStats) GetCriticalHitBonus() int32 {
+	if x != nil {
+		return x.CriticalHitBonus
+	}
+	return 0
+}
+
+func (x *WeaponStats) GetMaxCastPerTurn() int32 {
+	if x != nil {
+		return x.MaxCastPerTurn
+	}
+	return 0
+}
+
+func (x *WeaponStats) GetApCost() int32 {
+	if x != nil {
+		return x.ApCost
+	}
+	return 0
+}
+
+func (x *WeaponStats) GetRange() *Range {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+type RecipeEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemAnkamaId  int32                  `protobuf:"varint,1,opt,name=item_ankama_id,json=itemAnkamaId,proto3" json:"item_ankama_id,omitempty"`
+	ItemSubtype   string                 `protobuf:"bytes,2,opt,name=item_subtype,json=itemSubtype,proto3" json:"item_subtype,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeEntry) Reset() {
+	*x = RecipeEntry{}
+	mi := &file_doduapi_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeEntry) ProtoMessage() {}
+
+func (x *RecipeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeEntry.ProtoReflect.Descriptor instead.
+func (*RecipeEntry) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RecipeEntry) GetItemAnkamaId() int32 {
+	if x != nil {
+		return x.ItemAnkamaId
+	}
+	return 0
+}
+
+func (x *RecipeEntry) GetItemSubtype() string {
+	if x != nil {
+		return x.ItemSubtype
+	}
+	return ""
+}
+
+func (x *RecipeEntry) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type Item struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AnkamaId    int32                  `protobuf:"varint,1,opt,name=ankama_id,json=ankamaId,proto3" json:"ankama_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type        *ItemType              `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	ItemSubtype string                 `protobuf:"bytes,5,opt,name=item_subtype,json=itemSubtype,proto3" json:"item_subtype,omitempty"`
+	Level       int32                  `protobuf:"varint,6,opt,name=level,proto3" json:"level,omitempty"`
+	Pods        int32                  `protobuf:"varint,7,opt,name=pods,proto3" json:"pods,omitempty"`
+	ImageUrls   *ImageUrls             `protobuf:"bytes,8,opt,name=image_urls,json=imageUrls,proto3" json:"image_urls,omitempty"`
+	Effects     []*Effect              `protobuf:"bytes,9,rep,name=effects,proto3" json:"effects,omitempty"`
+	Conditions  *ConditionNode         `protobuf:"bytes,10,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	Recipe      []*RecipeEntry         `protobuf:"bytes,11,rep,name=recipe,proto3" json:"recipe,omitempty"`
+	ParentSet   *SetReference          `protobuf:"bytes,12,opt,name=parent_set,json=parentSet,proto3" json:"parent_set,omitempty"`
+	// Only set for weapons.
+	Weapon        *WeaponStats `protobuf:"bytes,13,opt,name=weapon,proto3" json:"weapon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_doduapi_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Item) GetAnkamaId() int32 {
+	if x != nil {
+		return x.AnkamaId
+	}
+	return 0
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Item) GetType() *ItemType {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *Item) GetItemSubtype() string {
+	if x != nil {
+		return x.ItemSubtype
+	}
+	return ""
+}
+
+func (x *Item) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Item) GetPods() int32 {
+	if x != nil {
+		return x.Pods
+	}
+	return 0
+}
+
+func (x *Item) GetImageUrls() *ImageUrls {
+	if x != nil {
+		return x.ImageUrls
+	}
+	return nil
+}
+
+func (x *Item) GetEffects() []*Effect {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+func (x *Item) GetConditions() *ConditionNode {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *Item) GetRecipe() []*RecipeEntry {
+	if x != nil {
+		return x.Recipe
+	}
+	return nil
+}
+
+func (x *Item) GetParentSet() *SetReference {
+	if x != nil {
+		return x.ParentSet
+	}
+	return nil
+}
+
+func (x *Item) GetWeapon() *WeaponStats {
+	if x != nil {
+		return x.Weapon
+	}
+	return nil
+}
+
+type SetBonus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemCount     int32                  `protobuf:"varint,1,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	Effects       []*Effect              `protobuf:"bytes,2,rep,name=effects,proto3" json:"effects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBonus) Reset() {
+	*x = SetBonus{}
+	mi := &file_doduapi_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBonus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBonus) ProtoMessage() {}
+
+func (x *SetBonus) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBonus.ProtoReflect.Descriptor instead.
+func (*SetBonus) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetBonus) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *SetBonus) GetEffects() []*Effect {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+type Set struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	AnkamaId              int32                  `protobuf:"varint,1,opt,name=ankama_id,json=ankamaId,proto3" json:"ankama_id,omitempty"`
+	Name                  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	EquipmentIds          []int32                `protobuf:"varint,3,rep,packed,name=equipment_ids,json=equipmentIds,proto3" json:"equipment_ids,omitempty"`
+	HighestEquipmentLevel int32                  `protobuf:"varint,4,opt,name=highest_equipment_level,json=highestEquipmentLevel,proto3" json:"highest_equipment_level,omitempty"`
+	ContainsCosmetics     bool                   `protobuf:"varint,5,opt,name=contains_cosmetics,json=containsCosmetics,proto3" json:"contains_cosmetics,omitempty"`
+	ContainsCosmeticsOnly bool                   `protobuf:"varint,6,opt,name=contains_cosmetics_only,json=containsCosmeticsOnly,proto3" json:"contains_cosmetics_only,omitempty"`
+	Bonuses               []*SetBonus            `protobuf:"bytes,7,rep,name=bonuses,proto3" json:"bonuses,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Set) Reset() {
+	*x = Set{}
+	mi := &file_doduapi_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Set) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Set) ProtoMessage() {}
+
+func (x *Set) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Set.ProtoReflect.Descriptor instead.
+func (*Set) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Set) GetAnkamaId() int32 {
+	if x != nil {
+		return x.AnkamaId
+	}
+	return 0
+}
+
+func (x *Set) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Set) GetEquipmentIds() []int32 {
+	if x != nil {
+		return x.EquipmentIds
+	}
+	return nil
+}
+
+func (x *Set) GetHighestEquipmentLevel() int32 {
+	if x != nil {
+		return x.HighestEquipmentLevel
+	}
+	return 0
+}
+
+func (x *Set) GetContainsCosmetics() bool {
+	if x != nil {
+		return x.ContainsCosmetics
+	}
+	return false
+}
+
+func (x *Set) GetContainsCosmeticsOnly() bool {
+	if x != nil {
+		return x.ContainsCosmeticsOnly
+	}
+	return false
+}
+
+func (x *Set) GetBonuses() []*SetBonus {
+	if x != nil {
+		return x.Bonuses
+	}
+	return nil
+}
+
+type MountFamily struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MountFamily) Reset() {
+	*x = MountFamily{}
+	mi := &file_doduapi_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MountFamily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MountFamily) ProtoMessage() {}
+
+func (x *MountFamily) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MountFamily.ProtoReflect.Descriptor instead.
+func (*MountFamily) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MountFamily) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MountFamily) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Mount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnkamaId      int32                  `protobuf:"varint,1,opt,name=ankama_id,json=ankamaId,proto3" json:"ankama_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Family        *MountFamily           `protobuf:"bytes,3,opt,name=family,proto3" json:"family,omitempty"`
+	ImageUrls     *ImageUrls             `protobuf:"bytes,4,opt,name=image_urls,json=imageUrls,proto3" json:"image_urls,omitempty"`
+	Effects       []*Effect              `protobuf:"bytes,5,rep,name=effects,proto3" json:"effects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mount) Reset() {
+	*x = Mount{}
+	mi := &file_doduapi_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mount) ProtoMessage() {}
+
+func (x *Mount) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mount.ProtoReflect.Descriptor instead.
+func (*Mount) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Mount) GetAnkamaId() int32 {
+	if x != nil {
+		return x.AnkamaId
+	}
+	return 0
+}
+
+func (x *Mount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mount) GetFamily() *MountFamily {
+	if x != nil {
+		return x.Family
+	}
+	return nil
+}
+
+func (x *Mount) GetImageUrls() *ImageUrls {
+	if x != nil {
+		return x.ImageUrls
+	}
+	return nil
+}
+
+func (x *Mount) GetEffects() []*Effect {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+type Recipe struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ResultAnkamaId int32                  `protobuf:"varint,1,opt,name=result_ankama_id,json=resultAnkamaId,proto3" json:"result_ankama_id,omitempty"`
+	Level          int32                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Entries        []*RecipeEntry         `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Recipe) Reset() {
+	*x = Recipe{}
+	mi := &file_doduapi_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recipe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recipe) ProtoMessage() {}
+
+func (x *Recipe) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recipe.ProtoReflect.Descriptor instead.
+func (*Recipe) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Recipe) GetResultAnkamaId() int32 {
+	if x != nil {
+		return x.ResultAnkamaId
+	}
+	return 0
+}
+
+func (x *Recipe) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Recipe) GetEntries() []*RecipeEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetAlmanaxRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lang  string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	// YYYY-MM-DD
+	Date string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	// Character level for reward_xp, between 1 and 200.
+	Level         *int32 `protobuf:"varint,3,opt,name=level,proto3,oneof" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlmanaxRequest) Reset() {
+	*x = GetAlmanaxRequest{}
+	mi := &file_doduapi_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlmanaxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlmanaxRequest) ProtoMessage() {}
+
+func (x *GetAlmanaxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlmanaxRequest.ProtoReflect.Descriptor instead.
+func (*GetAlmanaxRequest) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetAlmanaxRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GetAlmanaxRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetAlmanaxRequest) GetLevel() int32 {
+	if x != nil && x.Level != nil {
+		return *x.Level
+	}
+	return 0
+}
+
+type ListAlmanaxRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lang  string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	// YYYY-MM-DD, both inclusive.
+	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	BonusType     string `protobuf:"bytes,4,opt,name=bonus_type,json=bonusType,proto3" json:"bonus_type,omitempty"`
+	Level         *int32 `protobuf:"varint,5,opt,name=level,proto3,oneof" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlmanaxRequest) Reset() {
+	*x = ListAlmanaxRequest{}
+	mi := &file_doduapi_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlmanaxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlmanaxRequest) ProtoMessage() {}
+
+func (x *ListAlmanaxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlmanaxRequest.ProtoReflect.Descriptor instead.
+func (*ListAlmanaxRequest) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListAlmanaxRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *ListAlmanaxRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListAlmanaxRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListAlmanaxRequest) GetBonusType() string {
+	if x != nil {
+		return x.BonusType
+	}
+	return ""
+}
+
+func (x *ListAlmanaxRequest) GetLevel() int32 {
+	if x != nil && x.Level != nil {
+		return *x.Level
+	}
+	return 0
+}
+
+type AlmanaxBonusType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlmanaxBonusType) Reset() {
+	*x = AlmanaxBonusType{}
+	mi := &file_doduapi_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlmanaxBonusType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlmanaxBonusType) ProtoMessage() {}
+
+func (x *AlmanaxBonusType) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlmanaxBonusType.ProtoReflect.Descriptor instead.
+func (*AlmanaxBonusType) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AlmanaxBonusType) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlmanaxBonusType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AlmanaxBonus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Type          *AlmanaxBonusType      `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlmanaxBonus) Reset() {
+	*x = AlmanaxBonus{}
+	mi := &file_doduapi_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlmanaxBonus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlmanaxBonus) ProtoMessage() {}
+
+func (x *AlmanaxBonus) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlmanaxBonus.ProtoReflect.Descriptor instead.
+func (*AlmanaxBonus) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AlmanaxBonus) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AlmanaxBonus) GetType() *AlmanaxBonusType {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+type AlmanaxTributeItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnkamaId      int32                  `protobuf:"varint,1,opt,name=ankama_id,json=ankamaId,proto3" json:"ankama_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Subtype       string                 `protobuf:"bytes,3,opt,name=subtype,proto3" json:"subtype,omitempty"`
+	ImageUrls     *ImageUrls             `protobuf:"bytes,4,opt,name=image_urls,json=imageUrls,proto3" json:"image_urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlmanaxTributeItem) Reset() {
+	*x = AlmanaxTributeItem{}
+	mi := &file_doduapi_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlmanaxTributeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlmanaxTributeItem) ProtoMessage() {}
+
+func (x *AlmanaxTributeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlmanaxTributeItem.ProtoReflect.Descriptor instead.
+func (*AlmanaxTributeItem) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AlmanaxTributeItem) GetAnkamaId() int32 {
+	if x != nil {
+		return x.AnkamaId
+	}
+	return 0
+}
+
+func (x *AlmanaxTributeItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlmanaxTributeItem) GetSubtype() string {
+	if x != nil {
+		return x.Subtype
+	}
+	return ""
+}
+
+func (x *AlmanaxTributeItem) GetImageUrls() *ImageUrls {
+	if x != nil {
+		return x.ImageUrls
+	}
+	return nil
+}
+
+type AlmanaxTribute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *AlmanaxTributeItem    `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlmanaxTribute) Reset() {
+	*x = AlmanaxTribute{}
+	mi := &file_doduapi_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlmanaxTribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlmanaxTribute) ProtoMessage() {}
+
+func (x *AlmanaxTribute) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlmanaxTribute.ProtoReflect.Descriptor instead.
+func (*AlmanaxTribute) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AlmanaxTribute) GetItem() *AlmanaxTributeItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *AlmanaxTribute) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type AlmanaxDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Bonus         *AlmanaxBonus          `protobuf:"bytes,2,opt,name=bonus,proto3" json:"bonus,omitempty"`
+	RewardKamas   int32                  `protobuf:"varint,3,opt,name=reward_kamas,json=rewardKamas,proto3" json:"reward_kamas,omitempty"`
+	RewardXp      *int32                 `protobuf:"varint,4,opt,name=reward_xp,json=rewardXp,proto3,oneof" json:"reward_xp,omitempty"`
+	Tribute       *AlmanaxTribute        `protobuf:"bytes,5,opt,name=tribute,proto3" json:"tribute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlmanaxDay) Reset() {
+	*x = AlmanaxDay{}
+	mi := &file_doduapi_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlmanaxDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlmanaxDay) ProtoMessage() {}
+
+func (x *AlmanaxDay) ProtoReflect() protoreflect.Message {
+	mi := &file_doduapi_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlmanaxDay.ProtoReflect.Descriptor instead.
+func (*AlmanaxDay) Descriptor() ([]byte, []int) {
+	return file_doduapi_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AlmanaxDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *AlmanaxDay) GetBonus() *AlmanaxBonus {
+	if x != nil {
+		return x.Bonus
+	}
+	return nil
+}
+
+func (x *AlmanaxDay) GetRewardKamas() int32 {
+	if x != nil {
+		return x.RewardKamas
+	}
+	return 0
+}
+
+func (x *AlmanaxDay) GetRewardXp() int32 {
+	if x != nil && x.RewardXp != nil {
+		return *x.RewardXp
+	}
+	return 0
+}
+
+func (x *AlmanaxDay) GetTribute() *AlmanaxTribute {
+	if x != nil {
+		return x.Tribute
+	}
+	return nil
+}
+
+var File_doduapi_proto protoreflect.FileDescriptor
+
+const file_doduapi_proto_rawDesc = "" +
+	"\n" +
+	"\rdoduapi.proto\x12\n" +
+	"doduapi.v1\"!\n" +
+	"\vListRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\"A\n" +
+	"\x0eGetByIdRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x1b\n" +
+	"\tankama_id\x18\x02 \x01(\x05R\bankamaId\"w\n" +
+	"\x0eGetItemRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x1b\n" +
+	"\tankama_id\x18\x02 \x01(\x05R\bankamaId\x124\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x18.doduapi.v1.ItemCategoryR\bcategory\"\\\n" +
+	"\x10ListItemsRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x124\n" +
+	"\bcategory\x18\x02 \x01(\x0e2\x18.doduapi.v1.ItemCategoryR\bcategory\"O\n" +
+	"\tImageUrls\x12\x12\n" +
+	"\x04icon\x18\x01 \x01(\tR\x04icon\x12\x0e\n" +
+	"\x02sd\x18\x02 \x01(\tR\x02sd\x12\x0e\n" +
+	"\x02hq\x18\x03 \x01(\tR\x02hq\x12\x0e\n" +
+	"\x02hd\x18\x04 \x01(\tR\x02hd\"f\n" +
+	"\n" +
+	"EffectType\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\ais_meta\x18\x03 \x01(\bR\x06isMeta\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\"\xe0\x01\n" +
+	"\x06Effect\x12\x1f\n" +
+	"\vint_minimum\x18\x01 \x01(\x05R\n" +
+	"intMinimum\x12\x1f\n" +
+	"\vint_maximum\x18\x02 \x01(\x05R\n" +
+	"intMaximum\x12*\n" +
+	"\x04type\x18\x03 \x01(\v2\x16.doduapi.v1.EffectTypeR\x04type\x12$\n" +
+	"\x0eignore_int_min\x18\x04 \x01(\bR\fignoreIntMin\x12$\n" +
+	"\x0eignore_int_max\x18\x05 \x01(\bR\fignoreIntMax\x12\x1c\n" +
+	"\tformatted\x18\x06 \x01(\tR\tformatted\"6\n" +
+	"\x10ConditionElement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"|\n" +
+	"\tCondition\x12\x1a\n" +
+	"\boperator\x18\x01 \x01(\tR\boperator\x12\x1b\n" +
+	"\tint_value\x18\x02 \x01(\x05R\bintValue\x126\n" +
+	"\aelement\x18\x03 \x01(\v2\x1c.doduapi.v1.ConditionElementR\aelement\"\xb6\x01\n" +
+	"\rConditionNode\x123\n" +
+	"\tcondition\x18\x01 \x01(\v2\x15.doduapi.v1.ConditionR\tcondition\x12\x1d\n" +
+	"\n" +
+	"is_operand\x18\x02 \x01(\bR\tisOperand\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x125\n" +
+	"\bchildren\x18\x04 \x03(\v2\x19.doduapi.v1.ConditionNodeR\bchildren\".\n" +
+	"\bItemType\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"2\n" +
+	"\fSetReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"+\n" +
+	"\x05Range\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\x05R\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x05R\x03max\"\xe2\x01\n" +
+	"\vWeaponStats\x128\n" +
+	"\x18critical_hit_probability\x18\x01 \x01(\x05R\x16criticalHitProbability\x12,\n" +
+	"\x12critical_hit_bonus\x18\x02 \x01(\x05R\x10criticalHitBonus\x12)\n" +
+	"\x11max_cast_per_turn\x18\x03 \x01(\x05R\x0emaxCastPerTurn\x12\x17\n" +
+	"\aap_cost\x18\x04 \x01(\x05R\x06apCost\x12'\n" +
+	"\x05range\x18\x05 \x01(\v2\x11.doduapi.v1.RangeR\x05range\"r\n" +
+	"\vRecipeEntry\x12$\n" +
+	"\x0eitem_ankama_id\x18\x01 \x01(\x05R\fitemAnkamaId\x12!\n" +
+	"\fitem_subtype\x18\x02 \x01(\tR\vitemSubtype\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\x8a\x04\n" +
+	"\x04Item\x12\x1b\n" +
+	"\tankama_id\x18\x01 \x01(\x05R\bankamaId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12(\n" +
+	"\x04type\x18\x04 \x01(\v2\x14.doduapi.v1.ItemTypeR\x04type\x12!\n" +
+	"\fitem_subtype\x18\x05 \x01(\tR\vitemSubtype\x12\x14\n" +
+	"\x05level\x18\x06 \x01(\x05R\x05level\x12\x12\n" +
+	"\x04pods\x18\a \x01(\x05R\x04pods\x124\n" +
+	"\n" +
+	"image_urls\x18\b \x01(\v2\x15.doduapi.v1.ImageUrlsR\timageUrls\x12,\n" +
+	"\aeffects\x18\t \x03(\v2\x12.doduapi.v1.EffectR\aeffects\x129\n" +
+	"\n" +
+	"conditions\x18\n" +
+	" \x01(\v2\x19.doduapi.v1.ConditionNodeR\n" +
+	"conditions\x12/\n" +
+	"\x06recipe\x18\v \x03(\v2\x17.doduapi.v1.RecipeEntryR\x06recipe\x127\n" +
+	"\n" +
+	"parent_set\x18\f \x01(\v2\x18.doduapi.v1.SetReferenceR\tparentSet\x12/\n" +
+	"\x06weapon\x18\r \x01(\v2\x17.doduapi.v1.WeaponStatsR\x06weapon\"W\n" +
+	"\bSetBonus\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x01 \x01(\x05R\titemCount\x12,\n" +
+	"\aeffects\x18\x02 \x03(\v2\x12.doduapi.v1.EffectR\aeffects\"\xaa\x02\n" +
+	"\x03Set\x12\x1b\n" +
+	"\tankama_id\x18\x01 \x01(\x05R\bankamaId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\requipment_ids\x18\x03 \x03(\x05R\fequipmentIds\x126\n" +
+	"\x17highest_equipment_level\x18\x04 \x01(\x05R\x15highestEquipmentLevel\x12-\n" +
+	"\x12contains_cosmetics\x18\x05 \x01(\bR\x11containsCosmetics\x126\n" +
+	"\x17contains_cosmetics_only\x18\x06 \x01(\bR\x15containsCosmeticsOnly\x12.\n" +
+	"\abonuses\x18\a \x03(\v2\x14.doduapi.v1.SetBonusR\abonuses\"1\n" +
+	"\vMountFamily\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xcd\x01\n" +
+	"\x05Mount\x12\x1b\n" +
+	"\tankama_id\x18\x01 \x01(\x05R\bankamaId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12/\n" +
+	"\x06family\x18\x03 \x01(\v2\x17.doduapi.v1.MountFamilyR\x06family\x124\n" +
+	"\n" +
+	"image_urls\x18\x04 \x01(\v2\x15.doduapi.v1.ImageUrlsR\timageUrls\x12,\n" +
+	"\aeffects\x18\x05 \x03(\v2\x12.doduapi.v1.EffectR\aeffects\"{\n" +
+	"\x06Recipe\x12(\n" +
+	"\x10result_ankama_id\x18\x01 \x01(\x05R\x0eresultAnkamaId\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\x121\n" +
+	"\aentries\x18\x03 \x03(\v2\x17.doduapi.v1.RecipeEntryR\aentries\"`\n" +
+	"\x11GetAlmanaxRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x19\n" +
+	"\x05level\x18\x03 \x01(\x05H\x00R\x05level\x88\x01\x01B\b\n" +
+	"\x06_level\"\x90\x01\n" +
+	"\x12ListAlmanaxRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1d\n" +
+	"\n" +
+	"bonus_type\x18\x04 \x01(\tR\tbonusType\x12\x19\n" +
+	"\x05level\x18\x05 \x01(\x05H\x00R\x05level\x88\x01\x01B\b\n" +
+	"\x06_level\"6\n" +
+	"\x10AlmanaxBonusType\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"b\n" +
+	"\fAlmanaxBonus\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x120\n" +
+	"\x04type\x18\x02 \x01(\v2\x1c.doduapi.v1.AlmanaxBonusTypeR\x04type\"\x95\x01\n" +
+	"\x12AlmanaxTributeItem\x12\x1b\n" +
+	"\tankama_id\x18\x01 \x01(\x05R\bankamaId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\asubtype\x18\x03 \x01(\tR\asubtype\x124\n" +
+	"\n" +
+	"image_urls\x18\x04 \x01(\v2\x15.doduapi.v1.ImageUrlsR\timageUrls\"`\n" +
+	"\x0eAlmanaxTribute\x122\n" +
+	"\x04item\x18\x01 \x01(\v2\x1e.doduapi.v1.AlmanaxTributeItemR\x04item\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xd9\x01\n" +
+	"\n" +
+	"AlmanaxDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12.\n" +
+	"\x05bonus\x18\x02 \x01(\v2\x18.doduapi.v1.AlmanaxBonusR\x05bonus\x12!\n" +
+	"\freward_kamas\x18\x03 \x01(\x05R\vrewardKamas\x12 \n" +
+	"\treward_xp\x18\x04 \x01(\x05H\x00R\brewardXp\x88\x01\x01\x124\n" +
+	"\atribute\x18\x05 \x01(\v2\x1a.doduapi.v1.AlmanaxTributeR\atributeB\f\n" +
+	"\n" +
+	"_reward_xp*\xbc\x01\n" +
+	"\fItemCategory\x12\x1d\n" +
+	"\x19ITEM_CATEGORY_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ITEM_CATEGORY_EQUIPMENT\x10\x01\x12\x1d\n" +
+	"\x19ITEM_CATEGORY_CONSUMABLES\x10\x02\x12\x1b\n" +
+	"\x17ITEM_CATEGORY_RESOURCES\x10\x03\x12\x17\n" +
+	"\x13ITEM_CATEGORY_QUEST\x10\x04\x12\x1b\n" +
+	"\x17ITEM_CATEGORY_COSMETICS\x10\x052\xe7\x03\n" +
+	"\fEncyclopedia\x127\n" +
+	"\aGetItem\x12\x1a.doduapi.v1.GetItemRequest\x1a\x10.doduapi.v1.Item\x12=\n" +
+	"\tListItems\x12\x1c.doduapi.v1.ListItemsRequest\x1a\x10.doduapi.v1.Item0\x01\x125\n" +
+	"\x06GetSet\x12\x1a.doduapi.v1.GetByIdRequest\x1a\x0f.doduapi.v1.Set\x126\n" +
+	"\bListSets\x12\x17.doduapi.v1.ListRequest\x1a\x0f.doduapi.v1.Set0\x01\x129\n" +
+	"\bGetMount\x12\x1a.doduapi.v1.GetByIdRequest\x1a\x11.doduapi.v1.Mount\x12:\n" +
+	"\n" +
+	"ListMounts\x12\x17.doduapi.v1.ListRequest\x1a\x11.doduapi.v1.Mount0\x01\x12;\n" +
+	"\tGetRecipe\x12\x1a.doduapi.v1.GetByIdRequest\x1a\x12.doduapi.v1.Recipe\x12<\n" +
+	"\vListRecipes\x12\x17.doduapi.v1.ListRequest\x1a\x12.doduapi.v1.Recipe0\x012\xe2\x01\n" +
+	"\aAlmanax\x12C\n" +
+	"\n" +
+	"GetAlmanax\x12\x1d.doduapi.v1.GetAlmanaxRequest\x1a\x16.doduapi.v1.AlmanaxDay\x12G\n" +
+	"\vListAlmanax\x12\x1e.doduapi.v1.ListAlmanaxRequest\x1a\x16.doduapi.v1.AlmanaxDay0\x01\x12I\n" +
+	"\x0eListBonusTypes\x12\x17.doduapi.v1.ListRequest\x1a\x1c.doduapi.v1.AlmanaxBonusType0\x01B!Z\x1fgithub.com/dofusdude/doduapi/pbb\x06proto3"
+
+var (
+	file_doduapi_proto_rawDescOnce sync.Once
+	file_doduapi_proto_rawDescData []byte
+)
+
+func file_doduapi_proto_rawDescGZIP() []byte {
+	file_doduapi_proto_rawDescOnce.Do(func() {
+		file_doduapi_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_doduapi_proto_rawDesc), len(file_doduapi_proto_rawDesc)))
+	})
+	return file_doduapi_proto_rawDescData
+}
+
+var file_doduapi_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_doduapi_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_doduapi_proto_goTypes = []any{
+	(ItemCategory)(0),          // 0: doduapi.v1.ItemCategory
+	(*ListRequest)(nil),        // 1: doduapi.v1.ListRequest
+	(*GetByIdRequest)(nil),     // 2: doduapi.v1.GetByIdRequest
+	(*GetItemRequest)(nil),     // 3: doduapi.v1.GetItemRequest
+	(*ListItemsRequest)(nil),   // 4: doduapi.v1.ListItemsRequest
+	(*ImageUrls)(nil),          // 5: doduapi.v1.ImageUrls
+	(*EffectType)(nil),         // 6: doduapi.v1.EffectType
+	(*Effect)(nil),             // 7: doduapi.v1.Effect
+	(*ConditionElement)(nil),   // 8: doduapi.v1.ConditionElement
+	(*Condition)(nil),          // 9: doduapi.v1.Condition
+	(*ConditionNode)(nil),      // 10: doduapi.v1.ConditionNode
+	(*ItemType)(nil),           // 11: doduapi.v1.ItemType
+	(*SetReference)(nil),       // 12: doduapi.v1.SetReference
+	(*Range)(nil),              // 13: doduapi.v1.Range
+	(*WeaponStats)(nil),        // 14: doduapi.v1.WeaponStats
+	(*RecipeEntry)(nil),        // 15: doduapi.v1.RecipeEntry
+	(*Item)(nil),               // 16: doduapi.v1.Item
+	(*SetBonus)(nil),           // 17: doduapi.v1.SetBonus
+	(*Set)(nil),                // 18: doduapi.v1.Set
+	(*MountFamily)(nil),        // 19: doduapi.v1.MountFamily
+	(*Mount)(nil),              // 20: doduapi.v1.Mount
+	(*Recipe)(nil),             // 21: doduapi.v1.Recipe
+	(*GetAlmanaxRequest)(nil),  // 22: doduapi.v1.GetAlmanaxRequest
+	(*ListAlmanaxRequest)(nil), // 23: doduapi.v1.ListAlmanaxRequest
+	(*AlmanaxBonusType)(nil),   // 24: doduapi.v1.AlmanaxBonusType
+	(*AlmanaxBonus)(nil),       // 25: doduapi.v1.AlmanaxBonus
+	(*AlmanaxTributeItem)(nil), // 26: doduapi.v1.AlmanaxTributeItem
+	(*AlmanaxTribute)(nil),     // 27: doduapi.v1.AlmanaxTribute
+	(*AlmanaxDay)(nil),         // 28: doduapi.v1.AlmanaxDay
+}
+var file_doduapi_proto_depIdxs = []int32{
+	0,  // 0: doduapi.v1.GetItemRequest.category:type_name -> doduapi.v1.ItemCategory
+	0,  // 1: doduapi.v1.ListItemsRequest.category:type_name -> doduapi.v1.ItemCategory
+	6,  // 2: doduapi.v1.Effect.type:type_name -> doduapi.v1.EffectType
+	8,  // 3: doduapi.v1.Condition.element:type_name -> doduapi.v1.ConditionElement
+	9,  // 4: doduapi.v1.ConditionNode.condition:type_name -> doduapi.v1.Condition
+	10, // 5: doduapi.v1.ConditionNode.children:type_name -> doduapi.v1.ConditionNode
+	13, // 6: doduapi.v1.WeaponStats.range:type_name -> doduapi.v1.Range
+	11, // 7: doduapi.v1.Item.type:type_name -> doduapi.v1.ItemType
+	5,  // 8: doduapi.v1.Item.image_urls:type_name -> doduapi.v1.ImageUrls
+	7,  // 9: doduapi.v1.Item.effects:type_name -> doduapi.v1.Effect
+	10, // 10: doduapi.v1.Item.conditions:type_name -> doduapi.v1.ConditionNode
+	15, // 11: doduapi.v1.Item.recipe:type_name -> doduapi.v1.RecipeEntry
+	12, // 12: doduapi.v1.Item.parent_set:type_name -> doduapi.v1.SetReference
+	14, // 13: doduapi.v1.Item.weapon:type_name -> doduapi.v1.WeaponStats
+	7,  // 14: doduapi.v1.SetBonus.effects:type_name -> doduapi.v1.Effect
+	17, // 15: doduapi.v1.Set.bonuses:type_name -> doduapi.v1.SetBonus
+	19, // 16: doduapi.v1.Mount.family:type_name -> doduapi.v1.MountFamily
+	5,  // 17: doduapi.v1.Mount.image_urls:type_name -> doduapi.v1.ImageUrls
+	7,  // 18: doduapi.v1.Mount.effects:type_name -> doduapi.v1.Effect
+	15, // 19: doduapi.v1.Recipe.entries:type_name -> doduapi.v1.RecipeEntry
+	24, // 20: doduapi.v1.AlmanaxBonus.type:type_name -> doduapi.v1.AlmanaxBonusType
+	5,  // 21: doduapi.v1.AlmanaxTributeItem.image_urls:type_name -> doduapi.v1.ImageUrls
+	26, // 22: doduapi.v1.AlmanaxTribute.item:type_name -> doduapi.v1.AlmanaxTributeItem
+	25, // 23: doduapi.v1.AlmanaxDay.bonus:type_name -> doduapi.v1.AlmanaxBonus
+	27, // 24: doduapi.v1.AlmanaxDay.tribute:type_name -> doduapi.v1.AlmanaxTribute
+	3,  // 25: doduapi.v1.Encyclopedia.GetItem:input_type -> doduapi.v1.GetItemRequest
+	4,  // 26: doduapi.v1.Encyclopedia.ListItems:input_type -> doduapi.v1.ListItemsRequest
+	2,  // 27: doduapi.v1.Encyclopedia.GetSet:input_type -> doduapi.v1.GetByIdRequest
+	1,  // 28: doduapi.v1.Encyclopedia.ListSets:input_type -> doduapi.v1.ListRequest
+	2,  // 29: doduapi.v1.Encyclopedia.GetMount:input_type -> doduapi.v1.GetByIdRequest
+	1,  // 30: doduapi.v1.Encyclopedia.ListMounts:input_type -> doduapi.v1.ListRequest
+	2,  // 31: doduapi.v1.Encyclopedia.GetRecipe:input_type -> doduapi.v1.GetByIdRequest
+	1,  // 32: doduapi.v1.Encyclopedia.ListRecipes:input_type -> doduapi.v1.ListRequest
+	22, // 33: doduapi.v1.Almanax.GetAlmanax:input_type -> doduapi.v1.GetAlmanaxRequest
+	23, // 34: doduapi.v1.Almanax.ListAlmanax:input_type -> doduapi.v1.ListAlmanaxRequest
+	1,  // 35: doduapi.v1.Almanax.ListBonusTypes:input_type -> doduapi.v1.ListRequest
+	16, // 36: doduapi.v1.Encyclopedia.GetItem:output_type -> doduapi.v1.Item
+	16, // 37: doduapi.v1.Encyclopedia.ListItems:output_type -> doduapi.v1.Item
+	18, // 38: doduapi.v1.Encyclopedia.GetSet:output_type -> doduapi.v1.Set
+	18, // 39: doduapi.v1.Encyclopedia.ListSets:output_type -> doduapi.v1.Set
+	20, // 40: doduapi.v1.Encyclopedia.GetMount:output_type -> doduapi.v1.Mount
+	20, // 41: doduapi.v1.Encyclopedia.ListMounts:output_type -> doduapi.v1.Mount
+	21, // 42: doduapi.v1.Encyclopedia.GetRecipe:output_type -> doduapi.v1.Recipe
+	21, // 43: doduapi.v1.Encyclopedia.ListRecipes:output_type -> doduapi.v1.Recipe
+	28, // 44: doduapi.v1.Almanax.GetAlmanax:output_type -> doduapi.v1.AlmanaxDay
+	28, // 45: doduapi.v1.Almanax.ListAlmanax:output_type -> doduapi.v1.AlmanaxDay
+	24, // 46: doduapi.v1.Almanax.ListBonusTypes:output_type -> doduapi.v1.AlmanaxBonusType
+	36, // [36:47] is the sub-list for method output_type
+	25, // [25:36] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_doduapi_proto_init() }
+func file_doduapi_proto_init() {
+	if File_doduapi_proto != nil {
+		return
+	}
+	file_doduapi_proto_msgTypes[21].OneofWrappers = []any{}
+	file_doduapi_proto_msgTypes[22].OneofWrappers = []any{}
+	file_doduapi_proto_msgTypes[27].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_doduapi_proto_rawDesc), len(file_doduapi_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_doduapi_proto_goTypes,
+		DependencyIndexes: file_doduapi_proto_depIdxs,
+		EnumInfos:         file_doduapi_proto_enumTypes,
+		MessageInfos:      file_doduapi_proto_msgTypes,
+	}.Build()
+	File_doduapi_proto = out.File
+	file_doduapi_proto_goTypes = nil
+	file_doduapi_proto_depIdxs = nil
+}
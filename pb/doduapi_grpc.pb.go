@@ -0,0 +1,593 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: doduapi.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Encyclopedia_GetItem_FullMethodName     = "/doduapi.v1.Encyclopedia/GetItem"
+	Encyclopedia_ListItems_FullMethodName   = "/doduapi.v1.Encyclopedia/ListItems"
+	Encyclopedia_GetSet_FullMethodName      = "/doduapi.v1.Encyclopedia/GetSet"
+	Encyclopedia_ListSets_FullMethodName    = "/doduapi.v1.Encyclopedia/ListSets"
+	Encyclopedia_GetMount_FullMethodName    = "/doduapi.v1.Encyclopedia/GetMount"
+	Encyclopedia_ListMounts_FullMethodName  = "/doduapi.v1.Encyclopedia/ListMounts"
+	Encyclopedia_GetRecipe_FullMethodName   = "/doduapi.v1.Encyclopedia/GetRecipe"
+	Encyclopedia_ListRecipes_FullMethodName = "/doduapi.v1.Encyclopedia/ListRecipes"
+)
+
+// EncyclopediaClient is the client API for Encyclopedia service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Encyclopedia serves the same data as the REST encyclopedia routes. All requests need a lang (de, en, es, fr or pt).
+type EncyclopediaClient interface {
+	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error)
+	// Streams every item of a category, like /items/{category}/all but with all fields.
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Item], error)
+	GetSet(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Set, error)
+	ListSets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Set], error)
+	GetMount(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Mount, error)
+	ListMounts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Mount], error)
+	// Recipes are addressed by the ankama id of the crafted item.
+	GetRecipe(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Recipe, error)
+	ListRecipes(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Recipe], error)
+}
+
+type encyclopediaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEncyclopediaClient(cc grpc.ClientConnInterface) EncyclopediaClient {
+	return &encyclopediaClient{cc}
+}
+
+func (c *encyclopediaClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Item)
+	err := c.cc.Invoke(ctx, Encyclopedia_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *encyclopediaClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Item], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Encyclopedia_ServiceDesc.Streams[0], Encyclopedia_ListItems_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListItemsRequest, Item]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListItemsClient = grpc.ServerStreamingClient[Item]
+
+func (c *encyclopediaClient) GetSet(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Set, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Set)
+	err := c.cc.Invoke(ctx, Encyclopedia_GetSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *encyclopediaClient) ListSets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Set], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Encyclopedia_ServiceDesc.Streams[1], Encyclopedia_ListSets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, Set]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListSetsClient = grpc.ServerStreamingClient[Set]
+
+func (c *encyclopediaClient) GetMount(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Mount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mount)
+	err := c.cc.Invoke(ctx, Encyclopedia_GetMount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *encyclopediaClient) ListMounts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Mount], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Encyclopedia_ServiceDesc.Streams[2], Encyclopedia_ListMounts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, Mount]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListMountsClient = grpc.ServerStreamingClient[Mount]
+
+func (c *encyclopediaClient) GetRecipe(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Recipe, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, Encyclopedia_GetRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *encyclopediaClient) ListRecipes(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Recipe], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Encyclopedia_ServiceDesc.Streams[3], Encyclopedia_ListRecipes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, Recipe]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListRecipesClient = grpc.ServerStreamingClient[Recipe]
+
+// EncyclopediaServer is the server API for Encyclopedia service.
+// All implementations must embed UnimplementedEncyclopediaServer
+// for forward compatibility.
+//
+// Encyclopedia serves the same data as the REST encyclopedia routes. All requests need a lang (de, en, es, fr or pt).
+type EncyclopediaServer interface {
+	GetItem(context.Context, *GetItemRequest) (*Item, error)
+	// Streams every item of a category, like /items/{category}/all but with all fields.
+	ListItems(*ListItemsRequest, grpc.ServerStreamingServer[Item]) error
+	GetSet(context.Context, *GetByIdRequest) (*Set, error)
+	ListSets(*ListRequest, grpc.ServerStreamingServer[Set]) error
+	GetMount(context.Context, *GetByIdRequest) (*Mount, error)
+	ListMounts(*ListRequest, grpc.ServerStreamingServer[Mount]) error
+	// Recipes are addressed by the ankama id of the crafted item.
+	GetRecipe(context.Context, *GetByIdRequest) (*Recipe, error)
+	ListRecipes(*ListRequest, grpc.ServerStreamingServer[Recipe]) error
+	mustEmbedUnimplementedEncyclopediaServer()
+}
+
+// UnimplementedEncyclopediaServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEncyclopediaServer struct{}
+
+func (UnimplementedEncyclopediaServer) GetItem(context.Context, *GetItemRequest) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedEncyclopediaServer) ListItems(*ListItemsRequest, grpc.ServerStreamingServer[Item]) error {
+	return status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (UnimplementedEncyclopediaServer) GetSet(context.Context, *GetByIdRequest) (*Set, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSet not implemented")
+}
+func (UnimplementedEncyclopediaServer) ListSets(*ListRequest, grpc.ServerStreamingServer[Set]) error {
+	return status.Errorf(codes.Unimplemented, "method ListSets not implemented")
+}
+func (UnimplementedEncyclopediaServer) GetMount(context.Context, *GetByIdRequest) (*Mount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMount not implemented")
+}
+func (UnimplementedEncyclopediaServer) ListMounts(*ListRequest, grpc.ServerStreamingServer[Mount]) error {
+	return status.Errorf(codes.Unimplemented, "method ListMounts not implemented")
+}
+func (UnimplementedEncyclopediaServer) GetRecipe(context.Context, *GetByIdRequest) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecipe not implemented")
+}
+func (UnimplementedEncyclopediaServer) ListRecipes(*ListRequest, grpc.ServerStreamingServer[Recipe]) error {
+	return status.Errorf(codes.Unimplemented, "method ListRecipes not implemented")
+}
+func (UnimplementedEncyclopediaServer) mustEmbedUnimplementedEncyclopediaServer() {}
+func (UnimplementedEncyclopediaServer) testEmbeddedByValue()                      {}
+
+// UnsafeEncyclopediaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EncyclopediaServer will
+// result in compilation errors.
+type UnsafeEncyclopediaServer interface {
+	mustEmbedUnimplementedEncyclopediaServer()
+}
+
+func RegisterEncyclopediaServer(s grpc.ServiceRegistrar, srv EncyclopediaServer) {
+	// If the following call pancis, it indicates UnimplementedEncyclopediaServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Encyclopedia_ServiceDesc, srv)
+}
+
+func _Encyclopedia_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncyclopediaServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Encyclopedia_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncyclopediaServer).GetItem(ctx, req.(*GetItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Encyclopedia_ListItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListItemsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EncyclopediaServer).ListItems(m, &grpc.GenericServerStream[ListItemsRequest, Item]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListItemsServer = grpc.ServerStreamingServer[Item]
+
+func _Encyclopedia_GetSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncyclopediaServer).GetSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Encyclopedia_GetSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncyclopediaServer).GetSet(ctx, req.(*GetByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Encyclopedia_ListSets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EncyclopediaServer).ListSets(m, &grpc.GenericServerStream[ListRequest, Set]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListSetsServer = grpc.ServerStreamingServer[Set]
+
+func _Encyclopedia_GetMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncyclopediaServer).GetMount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Encyclopedia_GetMount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncyclopediaServer).GetMount(ctx, req.(*GetByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Encyclopedia_ListMounts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EncyclopediaServer).ListMounts(m, &grpc.GenericServerStream[ListRequest, Mount]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListMountsServer = grpc.ServerStreamingServer[Mount]
+
+func _Encyclopedia_GetRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncyclopediaServer).GetRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Encyclopedia_GetRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncyclopediaServer).GetRecipe(ctx, req.(*GetByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Encyclopedia_ListRecipes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EncyclopediaServer).ListRecipes(m, &grpc.GenericServerStream[ListRequest, Recipe]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Encyclopedia_ListRecipesServer = grpc.ServerStreamingServer[Recipe]
+
+// Encyclopedia_ServiceDesc is the grpc.ServiceDesc for Encyclopedia service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Encyclopedia_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "doduapi.v1.Encyclopedia",
+	HandlerType: (*EncyclopediaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetItem",
+			Handler:    _Encyclopedia_GetItem_Handler,
+		},
+		{
+			MethodName: "GetSet",
+			Handler:    _Encyclopedia_GetSet_Handler,
+		},
+		{
+			MethodName: "GetMount",
+			Handler:    _Encyclopedia_GetMount_Handler,
+		},
+		{
+			MethodName: "GetRecipe",
+			Handler:    _Encyclopedia_GetRecipe_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListItems",
+			Handler:       _Encyclopedia_ListItems_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListSets",
+			Handler:       _Encyclopedia_ListSets_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListMounts",
+			Handler:       _Encyclopedia_ListMounts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRecipes",
+			Handler:       _Encyclopedia_ListRecipes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "doduapi.proto",
+}
+
+const (
+	Almanax_GetAlmanax_FullMethodName     = "/doduapi.v1.Almanax/GetAlmanax"
+	Almanax_ListAlmanax_FullMethodName    = "/doduapi.v1.Almanax/ListAlmanax"
+	Almanax_ListBonusTypes_FullMethodName = "/doduapi.v1.Almanax/ListBonusTypes"
+)
+
+// AlmanaxClient is the client API for Almanax service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AlmanaxClient interface {
+	GetAlmanax(ctx context.Context, in *GetAlmanaxRequest, opts ...grpc.CallOption) (*AlmanaxDay, error)
+	ListAlmanax(ctx context.Context, in *ListAlmanaxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlmanaxDay], error)
+	ListBonusTypes(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlmanaxBonusType], error)
+}
+
+type almanaxClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlmanaxClient(cc grpc.ClientConnInterface) AlmanaxClient {
+	return &almanaxClient{cc}
+}
+
+func (c *almanaxClient) GetAlmanax(ctx context.Context, in *GetAlmanaxRequest, opts ...grpc.CallOption) (*AlmanaxDay, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AlmanaxDay)
+	err := c.cc.Invoke(ctx, Almanax_GetAlmanax_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *almanaxClient) ListAlmanax(ctx context.Context, in *ListAlmanaxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlmanaxDay], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Almanax_ServiceDesc.Streams[0], Almanax_ListAlmanax_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAlmanaxRequest, AlmanaxDay]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Almanax_ListAlmanaxClient = grpc.ServerStreamingClient[AlmanaxDay]
+
+func (c *almanaxClient) ListBonusTypes(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlmanaxBonusType], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Almanax_ServiceDesc.Streams[1], Almanax_ListBonusTypes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, AlmanaxBonusType]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Almanax_ListBonusTypesClient = grpc.ServerStreamingClient[AlmanaxBonusType]
+
+// AlmanaxServer is the server API for Almanax service.
+// All implementations must embed UnimplementedAlmanaxServer
+// for forward compatibility.
+type AlmanaxServer interface {
+	GetAlmanax(context.Context, *GetAlmanaxRequest) (*AlmanaxDay, error)
+	ListAlmanax(*ListAlmanaxRequest, grpc.ServerStreamingServer[AlmanaxDay]) error
+	ListBonusTypes(*ListRequest, grpc.ServerStreamingServer[AlmanaxBonusType]) error
+	mustEmbedUnimplementedAlmanaxServer()
+}
+
+// UnimplementedAlmanaxServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAlmanaxServer struct{}
+
+func (UnimplementedAlmanaxServer) GetAlmanax(context.Context, *GetAlmanaxRequest) (*AlmanaxDay, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlmanax not implemented")
+}
+func (UnimplementedAlmanaxServer) ListAlmanax(*ListAlmanaxRequest, grpc.ServerStreamingServer[AlmanaxDay]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAlmanax not implemented")
+}
+func (UnimplementedAlmanaxServer) ListBonusTypes(*ListRequest, grpc.ServerStreamingServer[AlmanaxBonusType]) error {
+	return status.Errorf(codes.Unimplemented, "method ListBonusTypes not implemented")
+}
+func (UnimplementedAlmanaxServer) mustEmbedUnimplementedAlmanaxServer() {}
+func (UnimplementedAlmanaxServer) testEmbeddedByValue()                 {}
+
+// UnsafeAlmanaxServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlmanaxServer will
+// result in compilation errors.
+type UnsafeAlmanaxServer interface {
+	mustEmbedUnimplementedAlmanaxServer()
+}
+
+func RegisterAlmanaxServer(s grpc.ServiceRegistrar, srv AlmanaxServer) {
+	// If the following call pancis, it indicates UnimplementedAlmanaxServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Almanax_ServiceDesc, srv)
+}
+
+func _Almanax_GetAlmanax_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlmanaxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlmanaxServer).GetAlmanax(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Almanax_GetAlmanax_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlmanaxServer).GetAlmanax(ctx, req.(*GetAlmanaxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Almanax_ListAlmanax_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAlmanaxRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlmanaxServer).ListAlmanax(m, &grpc.GenericServerStream[ListAlmanaxRequest, AlmanaxDay]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Almanax_ListAlmanaxServer = grpc.ServerStreamingServer[AlmanaxDay]
+
+func _Almanax_ListBonusTypes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlmanaxServer).ListBonusTypes(m, &grpc.GenericServerStream[ListRequest, AlmanaxBonusType]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Almanax_ListBonusTypesServer = grpc.ServerStreamingServer[AlmanaxBonusType]
+
+// Almanax_ServiceDesc is the grpc.ServiceDesc for Almanax service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Almanax_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "doduapi.v1.Almanax",
+	HandlerType: (*AlmanaxServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAlmanax",
+			Handler:    _Almanax_GetAlmanax_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListAlmanax",
+			Handler:       _Almanax_ListAlmanax_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListBonusTypes",
+			Handler:       _Almanax_ListBonusTypes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "doduapi.proto",
+}
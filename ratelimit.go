@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+)
+
+// rateLimitSweepInterval is how often buckets that refilled completely are dropped. A full bucket behaves like a
+// new one, so forgetting it changes nothing for the client.
+const rateLimitSweepInterval = time.Minute
+
+type tokenBucket struct {
+	tokens  float64
+	factor  float64
+	updated time.Time
+}
+
+// rateLimiter hands out tokens per client. Every client may send burst requests at once and gets rate tokens back
+// per second.
+type rateLimiter struct {
+	burst     float64
+	rate      float64
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{
+		burst:   float64(perMinute),
+		rate:    float64(perMinute) / 60,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// take consumes a token of the client, whose limit is multiplied by factor. It returns the tokens left and how long
+// it takes until the next token (when limited) or until the bucket is full again (when allowed).
+func (l *rateLimiter) take(client string, factor float64, now time.Time) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		for key, bucket := range l.buckets {
+			if bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate*bucket.factor >= l.burst*bucket.factor {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	burst := l.burst * factor
+	rate := l.rate * factor
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, updated: now}
+		l.buckets[client] = bucket
+	}
+
+	bucket.factor = factor
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*rate)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		return false, 0, time.Duration((1 - bucket.tokens) / rate * float64(time.Second))
+	}
+
+	bucket.tokens--
+	return true, int(bucket.tokens), time.Duration((burst - bucket.tokens) / rate * float64(time.Second))
+}
+
+// rateLimitClient identifies the client and returns its limit factor. Requests with an API key share the bucket
+// of the key, all others the bucket of their IP.
+func rateLimitClient(r *http.Request) (string, float64) {
+	if apiKey, ok := r.Context().Value("apiKey").(*database.ApiKey); ok {
+		return "key:" + strconv.FormatInt(apiKey.ID, 10), apiKey.RateLimitFactor
+	}
+
+	if config.RateLimitIpHeader != "" {
+		if ip := forwardedClientIp(r.Header.Values(config.RateLimitIpHeader), config.RateLimitTrustedHops); ip != "" {
+			return "ip:" + ip, 1
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host, 1
+}
+
+// forwardedClientIp returns the address the outermost trusted proxy appended to a header like X-Forwarded-For. The
+// client can send the header with any entries itself, so only the last trustedHops entries are believed, counted from
+// the right.
+func forwardedClientIp(values []string, trustedHops int) string {
+	var entries []string
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return entries[max(len(entries)-trustedHops, 0)]
+}
+
+func durationSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// rateLimit limits the requests of a route group to perMinute per client and reports the quota in RateLimit-*
+// headers. A limit of 0 or less disables it.
+func rateLimit(group string, perMinute int) func(http.Handler) http.Handler {
+	if perMinute <= 0 {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	limiter := newRateLimiter(perMinute)
+	allowed := utils.RateLimitDecisions.WithLabelValues(group, "allowed")
+	limited := utils.RateLimitDecisions.WithLabelValues(group, "limited")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client, factor := rateLimitClient(r)
+			ok, remaining, reset := limiter.take(client, factor, time.Now())
+			limit := int(float64(perMinute) * factor)
+
+			header := w.Header()
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=60", limit))
+			header.Set("RateLimit-Limit", strconv.Itoa(limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+			header.Set("RateLimit-Reset", durationSeconds(reset))
+
+			if !ok {
+				limited.Inc()
+				header.Set("Retry-After", durationSeconds(reset))
+				e.WriteTooManyRequestsResponse(w, fmt.Sprintf("The %s routes allow %d requests per minute.", group, limit))
+				return
+			}
+
+			allowed.Inc()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
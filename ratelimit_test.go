@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dofusdude/doduapi/config"
+	e "github.com/dofusdude/doduapi/errmsg"
+)
+
+func TestRateLimit(t *testing.T) {
+	handler := rateLimit("search", 2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/en/search", nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := request("1.2.3.4:5000"); w.Code != http.StatusOK {
+			t.Fatal("Expected request ", i, " to pass, got ", w.Code)
+		}
+	}
+
+	w := request("1.2.3.4:5001")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatal("Expected 429 with Retry-After, got ", w.Code, w.Header())
+	}
+
+	var apiErr e.ApiError
+	if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil || apiErr.Code != e.ERR_RATE_LIMITED {
+		t.Error("Expected an ApiError with code ", e.ERR_RATE_LIMITED, ", got ", w.Body.String())
+	}
+
+	if w := request("5.6.7.8:5000"); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "2" {
+		t.Error("Expected other clients to have their own bucket, got ", w.Code, w.Header())
+	}
+}
+
+func TestRateLimiterRefills(t *testing.T) {
+	limiter := newRateLimiter(60)
+	now := time.Now()
+
+	for i := 0; i < 60; i++ {
+		limiter.take("ip:1.2.3.4", 1, now)
+	}
+	if ok, _, retry := limiter.take("ip:1.2.3.4", 1, now); ok || retry != time.Second {
+		t.Error("Expected an empty bucket with a token after one second, got ", ok, retry)
+	}
+
+	if ok, remaining, _ := limiter.take("ip:1.2.3.4", 1, now.Add(3*time.Second)); !ok || remaining != 2 {
+		t.Error("Expected 3 refilled tokens after 3 seconds, got ", ok, remaining)
+	}
+
+	limiter.take("ip:5.6.7.8", 1, now)
+	limiter.take("ip:5.6.7.8", 1, now.Add(2*time.Minute)) // sweeps every bucket that refilled completely
+	if len(limiter.buckets) != 1 {
+		t.Error("Expected full buckets to be dropped, got ", len(limiter.buckets))
+	}
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	config.RateLimitIpHeader = "X-Forwarded-For"
+	config.RateLimitTrustedHops = 1
+	defer func() {
+		config.RateLimitIpHeader = ""
+		config.RateLimitTrustedHops = 0
+	}()
+
+	handler := rateLimit("search", 2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+
+	// the client makes up the first entries, the proxy appends the address it saw
+	codes := make([]int, 3)
+	for i := range codes {
+		r := httptest.NewRequest("GET", "/en/search", nil)
+		r.RemoteAddr = "10.0.0.1:5000"
+		r.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d, 198.51.100.7", i))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		codes[i] = w.Code
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Error("Expected spoofed entries to share the bucket of the real client, got ", codes)
+	}
+
+	cases := []struct {
+		values []string
+		hops   int
+		ip     string
+	}{
+		{[]string{"1.1.1.1, 2.2.2.2, 3.3.3.3"}, 1, "3.3.3.3"},
+		{[]string{"1.1.1.1, 2.2.2.2", "3.3.3.3"}, 2, "2.2.2.2"},
+		{[]string{"3.3.3.3"}, 2, "3.3.3.3"},
+		{nil, 1, ""},
+	}
+	for _, c := range cases {
+		if ip := forwardedClientIp(c.values, c.hops); ip != c.ip {
+			t.Error("Expected ", c.ip, " for ", c.values, " with ", c.hops, " hops, got ", ip)
+		}
+	}
+}
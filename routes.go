@@ -33,12 +33,8 @@ func FileServer(r chi.Router, path string, root http.FileSystem) {
 	})
 }
 
-func Router() chi.Router {
-	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(10 * time.Second))
-
+// apiBasePath is the path prefix of all API routes, for example /dofus3/v1.
+func apiBasePath() string {
 	var gameRelease string
 	if config.IsBeta {
 		gameRelease = "dofus3beta"
@@ -46,93 +42,141 @@ func Router() chi.Router {
 		gameRelease = "dofus3"
 	}
 
-	r.With(useCors).Route(fmt.Sprintf("/%s/v%d", gameRelease, DoduapiMajor), func(r chi.Router) {
+	return fmt.Sprintf("/%s/v%d", gameRelease, DoduapiMajor)
+}
 
-		if config.PublishFileServer {
-			imagesDir := http.Dir(filepath.Join(config.DockerMountDataPath, "data", "img"))
-			FileServer(r, "/img", imagesDir)
-		}
+func Router() chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+	r.Use(compress(config.CompressionMinSize))
+	r.Use(requestTimeout(10 * time.Second))
+
+	encyclopediaCache := cachePolicy(CachePolicy{MaxAge: config.CacheMaxAgeEncyclopedia, Responses: renderedResponses})
+	// the /all listings are streamed and too large to keep in memory, they are only revalidated
+	streamCache := cachePolicy(CachePolicy{MaxAge: config.CacheMaxAgeEncyclopedia})
+	searchCache := cachePolicy(CachePolicy{MaxAge: config.CacheMaxAgeSearch})
+	metaCache := cachePolicy(CachePolicy{MaxAge: config.CacheMaxAgeMeta})
+	almanaxCache := cachePolicy(CachePolicy{Validity: almanaxDayValidity})
+
+	searchLimit := rateLimit("search", config.RateLimitSearch)
+	listLimit := rateLimit("list", config.RateLimitList)
+	allLimit := rateLimit("all", config.RateLimitAll)
+	almanaxLimit := rateLimit("almanax", config.RateLimitAlmanax)
+
+	// languageRoutes answer in one language. They are served below /{lang} and, with the language from
+	// Accept-Language, without it.
+	languageRoutes := func(r chi.Router) {
+		r.Route("/search", func(r chi.Router) {
+			r.With(searchLimit, searchCache).Get("/", SearchAllIndices)
+		})
 
-		r.Route("/update", func(r chi.Router) {
-			r.Post(fmt.Sprintf("/%s", config.UpdateHookToken), UpdateHandler)
+		r.With(listLimit).Post("/batch", BatchHandler)
+
+		r.Route("/almanax", func(r chi.Router) {
+			r.With(almanaxLimit, almanaxCache).Get("/", almanax.GetAlmanaxRange)
+			r.With(almanaxLimit, almanaxCache, dateExtractor).Get("/{date}", almanax.GetAlmanaxSingle)
 		})
 
-		r.Route("/meta", func(r chi.Router) {
-			r.Get("/version", GetGameVersion)
-			r.Get("/elements", ListEffectConditionElements)
-			r.Get("/items/types", ListItemTypeIds)
-			r.Get("/search/types", ListSearchAllTypes)
-
-			r.With(languageChecker).Route("/{lang}/almanax/bonuses", func(r chi.Router) {
-				r.Get("/", almanax.ListBonuses)
-				r.Get("/search", almanax.SearchBonuses)
+		r.Route("/items", func(r chi.Router) {
+			r.Route("/consumables", func(r chi.Router) {
+				r.With(listLimit, encyclopediaCache, paginate).Get("/", ListConsumables)
+				r.With(allLimit, streamCache, streamListing).Get("/all", ListAllConsumables)
+				r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleConsumableHandler)
+				r.With(searchLimit, searchCache).Get("/search", SearchConsumables)
 			})
-		})
 
-		r.With(languageChecker).Route("/{lang}", func(r chi.Router) {
-			r.Route("/search", func(r chi.Router) {
-				r.Get("/", SearchAllIndices)
+			r.Route("/resources", func(r chi.Router) {
+				r.With(listLimit, encyclopediaCache, paginate).Get("/", ListResources)
+				r.With(allLimit, streamCache, streamListing).Get("/all", ListAllResources)
+				r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleResourceHandler)
+				r.With(searchLimit, searchCache).Get("/search", SearchResources)
 			})
 
-			r.Route("/almanax", func(r chi.Router) {
-				r.Get("/", almanax.GetAlmanaxRange)
-				r.With(dateExtractor).Get("/{date}", almanax.GetAlmanaxSingle)
+			r.Route("/equipment", func(r chi.Router) {
+				r.With(listLimit, encyclopediaCache, paginate).Get("/", ListEquipment)
+				r.With(allLimit, streamCache, streamListing).Get("/all", ListAllEquipment)
+				r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleEquipmentHandler)
+				r.With(listLimit, ankamaIdExtractor).Post("/{ankamaId}/conditions/evaluate", EvaluateEquipmentConditionsHandler)
+				r.With(searchLimit, searchCache).Get("/search", SearchEquipment)
 			})
 
-			r.Route("/items", func(r chi.Router) {
-				r.Route("/consumables", func(r chi.Router) {
-					r.With(paginate).Get("/", ListConsumables)
-					r.With(disablePaginate).Get("/all", ListAllConsumables)
-					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleConsumableHandler)
-					r.Get("/search", SearchConsumables)
-				})
-
-				r.Route("/resources", func(r chi.Router) {
-					r.With(paginate).Get("/", ListResources)
-					r.With(disablePaginate).Get("/all", ListAllResources)
-					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleResourceHandler)
-					r.Get("/search", SearchResources)
-				})
-
-				r.Route("/equipment", func(r chi.Router) {
-					r.With(paginate).Get("/", ListEquipment)
-					r.With(disablePaginate).Get("/all", ListAllEquipment)
-					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleEquipmentHandler)
-					r.Get("/search", SearchEquipment)
-				})
-
-				r.Route("/quest", func(r chi.Router) {
-					r.With(paginate).Get("/", ListQuestItems)
-					r.With(disablePaginate).Get("/all", ListAllQuestItems)
-					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleQuestItemHandler)
-					r.Get("/search", SearchQuestItems)
-				})
-
-				r.Route("/cosmetics", func(r chi.Router) {
-					r.With(paginate).Get("/", ListCosmetics)
-					r.With(disablePaginate).Get("/all", ListAllCosmetics)
-					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleCosmeticHandler)
-					r.Get("/search", SearchCosmetics)
-				})
-
-				r.Get("/search", SearchAllItems)
+			r.Route("/weapons", func(r chi.Router) {
+				r.With(listLimit, encyclopediaCache, paginate).Get("/", ListWeapons)
+				r.With(allLimit, streamCache, streamListing).Get("/all", ListAllWeapons)
+				r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleWeaponHandler)
+				r.With(searchLimit, searchCache).Get("/search", SearchWeapons)
+			})
 
+			r.Route("/quest", func(r chi.Router) {
+				r.With(listLimit, encyclopediaCache, paginate).Get("/", ListQuestItems)
+				r.With(allLimit, streamCache, streamListing).Get("/all", ListAllQuestItems)
+				r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleQuestItemHandler)
+				r.With(searchLimit, searchCache).Get("/search", SearchQuestItems)
 			})
 
-			r.Route("/mounts", func(r chi.Router) {
-				r.With(paginate).Get("/", ListMounts)
-				r.With(disablePaginate).Get("/all", ListAllMounts)
-				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleMountHandler)
-				r.Get("/search", SearchMounts)
+			r.Route("/cosmetics", func(r chi.Router) {
+				r.With(listLimit, encyclopediaCache, paginate).Get("/", ListCosmetics)
+				r.With(allLimit, streamCache, streamListing).Get("/all", ListAllCosmetics)
+				r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleCosmeticHandler)
+				r.With(searchLimit, searchCache).Get("/search", SearchCosmetics)
 			})
 
-			r.Route("/sets", func(r chi.Router) {
-				r.With(paginate).Get("/", ListSets)
-				r.With(disablePaginate).Get("/all", ListAllSets)
-				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleSetHandler)
-				r.Get("/search", SearchSets)
+			r.With(searchLimit, searchCache).Get("/search", SearchAllItems)
+			r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleItemHandler)
+
+		})
+
+		r.Route("/mounts", func(r chi.Router) {
+			r.With(listLimit, encyclopediaCache, paginate).Get("/", ListMounts)
+			r.With(allLimit, streamCache, streamListing).Get("/all", ListAllMounts)
+			r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleMountHandler)
+			r.With(searchLimit, searchCache).Get("/search", SearchMounts)
+		})
+
+		r.Route("/sets", func(r chi.Router) {
+			r.With(listLimit, encyclopediaCache, paginate).Get("/", ListSets)
+			r.With(allLimit, streamCache, streamListing).Get("/all", ListAllSets)
+			r.With(listLimit, encyclopediaCache, ankamaIdExtractor).Get("/{ankamaId}", GetSingleSetHandler)
+			r.With(searchLimit, searchCache).Get("/search", SearchSets)
+		})
+	}
+
+	r.With(useCors).Route(apiBasePath(), func(r chi.Router) {
+		r.Use(authenticate)
+
+		if config.PublishFileServer {
+			imagesDir := http.Dir(filepath.Join(config.DockerMountDataPath, "data", "img"))
+			FileServer(r.With(staticCache(config.CacheMaxAgeImages)), "/img", imagesDir)
+		}
+
+		r.With(searchLimit, searchCache).Get("/graphql", GraphqlHandler)
+		r.With(searchLimit).Post("/graphql", GraphqlHandler)
+
+		r.Route("/update", func(r chi.Router) {
+			r.Post(fmt.Sprintf("/%s", config.UpdateHookToken), UpdateHandler)
+		})
+
+		r.Route("/meta", func(r chi.Router) {
+			r.With(metaCache).Get("/version", GetGameVersion)
+			r.With(metaCache).Get("/elements", ListEffectConditionElements)
+			r.With(metaCache).Get("/items/types", ListItemTypeIds)
+			r.With(metaCache).Get("/search/types", ListSearchAllTypes)
+			r.With(metaCache).Get("/openapi.json", GetOpenapiSpec)
+			r.With(metaCache).Get("/docs", GetOpenapiDocs)
+			r.Get("/usage", GetApiKeyUsageHandler)
+
+			r.With(languageChecker, negotiateFormat, metaCache).Get("/{lang}/items/types", ListItemTypes)
+			r.With(languageChecker, negotiateFormat, metaCache).Get("/{lang}/taxonomy", GetTaxonomy)
+			r.With(languageChecker, negotiateFormat, metaCache).Get("/{lang}/effects", ListEffects)
+			r.With(languageChecker, negotiateFormat).Route("/{lang}/almanax/bonuses", func(r chi.Router) {
+				r.With(almanaxLimit, metaCache).Get("/", almanax.ListBonuses)
+				r.With(searchLimit, searchCache).Get("/search", almanax.SearchBonuses)
 			})
 		})
+
+		r.With(languageChecker, negotiateFormat).Route("/{lang}", languageRoutes)
+		r.With(negotiateLanguage, negotiateFormat).Group(languageRoutes)
 	})
 
 	return r
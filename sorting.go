@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+var (
+	effectSortKeyRe = regexp.MustCompile(`^effects\.([0-9]+)\.(min|max)$`)
+
+	itemSortFields  = []string{"ankama_id", "level", "name", "pods"}
+	setSortFields   = []string{"ankama_id", "level", "name", "items"}
+	mountSortFields = []string{"ankama_id", "name", "family.id"}
+)
+
+// SortKey is one entry of the sort parameter, for example "-level" or "effects.12.max".
+type SortKey struct {
+	Field     string
+	ElementId int    // only for effect keys
+	Bound     string // "min" or "max", only for effect keys
+	Desc      bool
+}
+
+func (k SortKey) String() string {
+	var field string
+	if k.Field == "effects" {
+		field = fmt.Sprintf("effects.%d.%s", k.ElementId, k.Bound)
+	} else {
+		field = k.Field
+	}
+
+	if k.Desc {
+		return "-" + field
+	}
+	return field
+}
+
+// parseSortParam parses a comma separated list of sort keys. A leading "-" sorts descending.
+// The legacy sort[level]=asc|desc parameter is used when no sort parameter is given.
+func parseSortParam(sortParam string, legacySortLevel string, allowedFields []string) ([]SortKey, error) {
+	if sortParam == "" {
+		switch legacySortLevel {
+		case "":
+			return nil, nil
+		case "asc":
+			return []SortKey{{Field: "level"}}, nil
+		case "desc":
+			return []SortKey{{Field: "level", Desc: true}}, nil
+		default:
+			return nil, fmt.Errorf("sort[level] must be asc or desc")
+		}
+	}
+
+	var keys []SortKey
+	for _, rawKey := range strings.Split(sortParam, ",") {
+		rawKey = strings.TrimSpace(strings.ToLower(rawKey))
+		if rawKey == "" {
+			continue
+		}
+
+		var key SortKey
+		if after, ok := strings.CutPrefix(rawKey, "-"); ok {
+			key.Desc = true
+			rawKey = after
+		} else {
+			rawKey = strings.TrimPrefix(rawKey, "+")
+		}
+
+		if groups := effectSortKeyRe.FindStringSubmatch(rawKey); groups != nil {
+			elementId, err := strconv.Atoi(groups[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid element id in sort key %s", rawKey)
+			}
+			key.Field = "effects"
+			key.ElementId = elementId
+			key.Bound = groups[2]
+		} else {
+			allowed := false
+			for _, field := range allowedFields {
+				if field == rawKey {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return nil, fmt.Errorf("unknown sort key %s", rawKey)
+			}
+			key.Field = rawKey
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func validateSortKeys(keys []SortKey, txn *memdb.Txn) error {
+	for _, key := range keys {
+		if key.Field != "effects" {
+			continue
+		}
+		exists, err := elementExists(key.ElementId, txn)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("unknown element id in sort key %s", key)
+		}
+	}
+	return nil
+}
+
+// entitySorter holds the state for sorting one listing. Collators are not safe for concurrent use, so create one per request.
+type entitySorter struct {
+	keys     []SortKey
+	lang     string
+	collator *collate.Collator
+}
+
+func newEntitySorter(keys []SortKey, lang string) *entitySorter {
+	return &entitySorter{
+		keys:     keys,
+		lang:     lang,
+		collator: collate.New(language.Make(lang)),
+	}
+}
+
+func compareInts(a int, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// effectSortValue returns the lowest "min" or the highest "max" value of all effects with the element id.
+func effectSortValue(effects []mapping.MappedMultilangEffect, elementId int, bound string) (int, bool) {
+	value := 0
+	found := false
+	for i := range effects {
+		if effects[i].ElementId != elementId {
+			continue
+		}
+
+		low, high, numeric := effectBounds(&effects[i])
+		if !numeric {
+			continue
+		}
+
+		if bound == "min" {
+			if !found || low < value {
+				value = low
+			}
+		} else {
+			if !found || high > value {
+				value = high
+			}
+		}
+		found = true
+	}
+	return value, found
+}
+
+// compareEffects orders by effect value. Entities without the effect always go last, regardless of the direction.
+func compareEffects(a []mapping.MappedMultilangEffect, b []mapping.MappedMultilangEffect, key SortKey) (int, bool) {
+	aValue, aFound := effectSortValue(a, key.ElementId, key.Bound)
+	bValue, bFound := effectSortValue(b, key.ElementId, key.Bound)
+	if !aFound || !bFound {
+		if aFound == bFound {
+			return 0, true
+		}
+		if aFound {
+			return -1, true
+		}
+		return 1, true
+	}
+	return compareInts(aValue, bValue), false
+}
+
+// sortEntities sorts stable by the sort keys and uses the ankama id as the final tie breaker, so pages are deterministic.
+// compare returns the ascending order for a key and whether it must not be reversed for descending keys.
+func sortEntities[T any](entities []T, keys []SortKey, compare func(a T, b T, key SortKey) (int, bool), id func(T) int) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		for _, key := range keys {
+			order, absolute := compare(entities[i], entities[j], key)
+			if order == 0 {
+				continue
+			}
+			if key.Desc && !absolute {
+				order = -order
+			}
+			return order < 0
+		}
+		return id(entities[i]) < id(entities[j])
+	})
+}
+
+func (s *entitySorter) SortItems(items []*mapping.MappedMultilangItemUnity) {
+	sortEntities(items, s.keys, func(a *mapping.MappedMultilangItemUnity, b *mapping.MappedMultilangItemUnity, key SortKey) (int, bool) {
+		switch key.Field {
+		case "ankama_id":
+			return compareInts(a.AnkamaId, b.AnkamaId), false
+		case "level":
+			return compareInts(a.Level, b.Level), false
+		case "name":
+			return s.collator.CompareString(a.Name[s.lang], b.Name[s.lang]), false
+		case "pods":
+			return compareInts(a.Pods, b.Pods), false
+		case "critical_hit_probability":
+			return compareInts(a.CriticalHitProbability, b.CriticalHitProbability), false
+		case "critical_hit_bonus":
+			return compareInts(a.CriticalHitBonus, b.CriticalHitBonus), false
+		case "ap_cost":
+			return compareInts(a.ApCost, b.ApCost), false
+		case "effects":
+			return compareEffects(a.Effects, b.Effects, key)
+		}
+		return 0, false
+	}, func(item *mapping.MappedMultilangItemUnity) int {
+		return item.AnkamaId
+	})
+}
+
+func (s *entitySorter) SortSets(sets []*mapping.MappedMultilangSetUnity) {
+	sortEntities(sets, s.keys, func(a *mapping.MappedMultilangSetUnity, b *mapping.MappedMultilangSetUnity, key SortKey) (int, bool) {
+		switch key.Field {
+		case "ankama_id":
+			return compareInts(a.AnkamaId, b.AnkamaId), false
+		case "level":
+			return compareInts(a.Level, b.Level), false
+		case "name":
+			return s.collator.CompareString(a.Name[s.lang], b.Name[s.lang]), false
+		case "items":
+			return compareInts(len(a.ItemIds), len(b.ItemIds)), false
+		case "effects":
+			return compareEffects(setEffects(a), setEffects(b), key)
+		}
+		return 0, false
+	}, func(set *mapping.MappedMultilangSetUnity) int {
+		return set.AnkamaId
+	})
+}
+
+func (s *entitySorter) SortMounts(mounts []*mapping.MappedMultilangItemUnity) {
+	sortEntities(mounts, s.keys, func(a *mapping.MappedMultilangItemUnity, b *mapping.MappedMultilangItemUnity, key SortKey) (int, bool) {
+		switch key.Field {
+		case "ankama_id":
+			return compareInts(a.AnkamaId, b.AnkamaId), false
+		case "name":
+			return s.collator.CompareString(a.Name[s.lang], b.Name[s.lang]), false
+		case "family.id":
+			return compareInts(a.Type.ItemTypeId, b.Type.ItemTypeId), false
+		case "effects":
+			return compareEffects(a.Effects, b.Effects, key)
+		}
+		return 0, false
+	}, func(mount *mapping.MappedMultilangItemUnity) int {
+		return mount.AnkamaId
+	})
+}
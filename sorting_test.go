@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	mapping "github.com/dofusdude/dodumap"
+)
+
+func TestParseSortParam(t *testing.T) {
+	keys, err := parseSortParam("-level,name,effects.12.max", "", itemSortFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"-level", "name", "effects.12.max"}
+	if len(keys) != len(expected) {
+		t.Fatal("Expected ", len(expected), " keys, got ", len(keys))
+	}
+
+	for i, key := range keys {
+		if key.String() != expected[i] {
+			t.Error("Expected ", expected[i], ", got ", key.String())
+		}
+	}
+
+	if _, err = parseSortParam("-weight", "", itemSortFields); err == nil {
+		t.Error("Expected error for unknown sort key")
+	}
+
+	keys, err = parseSortParam("", "desc", itemSortFields)
+	if err != nil || len(keys) != 1 || keys[0].String() != "-level" {
+		t.Error("Expected legacy sort[level]=desc to map to -level, got ", keys)
+	}
+}
+
+func TestSortItemsDeterministic(t *testing.T) {
+	items := []*mapping.MappedMultilangItemUnity{
+		{AnkamaId: 4, Level: 100, Name: map[string]string{"fr": "Épée"}},
+		{AnkamaId: 3, Level: 200, Name: map[string]string{"fr": "Arc"}},
+		{AnkamaId: 2, Level: 100, Name: map[string]string{"fr": "Epée"}, Effects: []mapping.MappedMultilangEffect{{ElementId: 1, Min: 10, Max: 20}}},
+		{AnkamaId: 1, Level: 100, Name: map[string]string{"fr": "Dague"}},
+	}
+
+	keys, _ := parseSortParam("-level,name", "", itemSortFields)
+	newEntitySorter(keys, "fr").SortItems(items)
+
+	expected := []int{3, 1, 2, 4}
+	for i, item := range items {
+		if item.AnkamaId != expected[i] {
+			t.Error("Expected ", expected[i], " at ", i, ", got ", item.AnkamaId)
+		}
+	}
+
+	// items without the effect go last in both directions
+	keys, _ = parseSortParam("-effects.1.max", "", itemSortFields)
+	newEntitySorter(keys, "fr").SortItems(items)
+
+	expected = []int{2, 1, 3, 4}
+	for i, item := range items {
+		if item.AnkamaId != expected[i] {
+			t.Error("Expected ", expected[i], " at ", i, ", got ", item.AnkamaId)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+)
+
+// weaponSuperTypeId is the super type of all weapons, which the equipment endpoints render as APIWeapon.
+const weaponSuperTypeId = 2
+
+// itemCategoryNames names the categories the API serves. The game data only has names for item types, so these are
+// translated by hand and only for de, en, es, fr and pt. Languages the game data adds later get empty names here,
+// which the translation fallback of the response fills, and need an entry to be translated.
+var itemCategoryNames = map[int]map[string]string{
+	0: {"de": "Ausrüstung", "en": "Equipment", "es": "Equipamiento", "fr": "Équipements", "pt": "Equipamentos"},
+	1: {"de": "Verbrauchsgegenstände", "en": "Consumables", "es": "Consumibles", "fr": "Consommables", "pt": "Consumíveis"},
+	2: {"de": "Ressourcen", "en": "Resources", "es": "Recursos", "fr": "Ressources", "pt": "Recursos"},
+	3: {"de": "Questgegenstände", "en": "Quest items", "es": "Objetos de misión", "fr": "Objets de quête", "pt": "Objetos de missão"},
+	5: {"de": "Kosmetik", "en": "Cosmetics", "es": "Cosméticos", "fr": "Cosmétiques", "pt": "Cosméticos"},
+}
+
+// itemSuperTypeNames names super types with more than one item type. Super types with a single item type are named
+// after it, the others stay unnamed. Translated by hand like itemCategoryNames.
+var itemSuperTypeNames = map[int]map[string]string{
+	weaponSuperTypeId: {"de": "Waffen", "en": "Weapons", "es": "Armas", "fr": "Armes", "pt": "Armas"},
+}
+
+type APITaxonomyItemType struct {
+	Id         int64  `json:"id"`
+	AnkamaId   int    `json:"ankama_id"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name" localized:"true"`
+	Count      int    `json:"count"`
+	MinLevel   int    `json:"min_level"`
+	MaxLevel   int    `json:"max_level"`
+	IsMount    bool   `json:"is_mount"`
+	IsWeapon   bool   `json:"is_weapon"`
+	IsCosmetic bool   `json:"is_cosmetic"`
+}
+
+type APITaxonomySuperType struct {
+	Id        int                   `json:"id"`
+	Name      string                `json:"name,omitempty" localized:"true"`
+	Count     int                   `json:"count"`
+	MinLevel  int                   `json:"min_level"`
+	MaxLevel  int                   `json:"max_level"`
+	ItemTypes []APITaxonomyItemType `json:"item_types"`
+}
+
+type APITaxonomyCategory struct {
+	Id         int                    `json:"id"`
+	Slug       string                 `json:"slug"`
+	Name       string                 `json:"name" localized:"true"`
+	Count      int                    `json:"count"`
+	MinLevel   int                    `json:"min_level"`
+	MaxLevel   int                    `json:"max_level"`
+	SuperTypes []APITaxonomySuperType `json:"super_types"`
+}
+
+// taxonomyNode groups item types by category or super type and sums up their counts and level ranges.
+type taxonomyNode struct {
+	id        int
+	itemTypes []*ItemTypeDbEntry
+	count     int
+	minLevel  int
+	maxLevel  int
+}
+
+func (node *taxonomyNode) add(itemType *ItemTypeDbEntry) {
+	if node.count == 0 || itemType.MinLevel < node.minLevel {
+		node.minLevel = itemType.MinLevel
+	}
+	if node.count == 0 || itemType.MaxLevel > node.maxLevel {
+		node.maxLevel = itemType.MaxLevel
+	}
+	node.count += itemType.ItemCount
+	node.itemTypes = append(node.itemTypes, itemType)
+}
+
+// groupItemTypes groups the item types by a key, ordered by the key. The item types keep their order.
+func groupItemTypes(itemTypes []*ItemTypeDbEntry, key func(*ItemTypeDbEntry) int) []*taxonomyNode {
+	nodes := make(map[int]*taxonomyNode)
+	var ordered []*taxonomyNode
+	for _, itemType := range itemTypes {
+		node, ok := nodes[key(itemType)]
+		if !ok {
+			node = &taxonomyNode{id: key(itemType)}
+			nodes[node.id] = node
+			ordered = append(ordered, node)
+		}
+		node.add(itemType)
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].id < ordered[j].id
+	})
+	return ordered
+}
+
+func RenderTaxonomyItemType(itemType *ItemTypeDbEntry, lang string) APITaxonomyItemType {
+	return APITaxonomyItemType{
+		Id:         itemType.ID,
+		AnkamaId:   itemType.AnkamaID,
+		Slug:       itemType.Slug,
+		Name:       itemType.Names[lang],
+		Count:      itemType.ItemCount,
+		MinLevel:   itemType.MinLevel,
+		MaxLevel:   itemType.MaxLevel,
+		IsMount:    mountEquipmentTypeIds[itemType.AnkamaID],
+		IsWeapon:   itemType.SuperTypeId == weaponSuperTypeId,
+		IsCosmetic: itemType.CategoryId == 5,
+	}
+}
+
+// RenderTaxonomy builds the category, super type and item type tree of the item types.
+func RenderTaxonomy(itemTypes []*ItemTypeDbEntry, lang string) []APITaxonomyCategory {
+	categories := make([]APITaxonomyCategory, 0)
+	for _, category := range groupItemTypes(itemTypes, func(itemType *ItemTypeDbEntry) int { return itemType.CategoryId }) {
+		renderedCategory := APITaxonomyCategory{
+			Id:         category.id,
+			Slug:       utils.CategoryIdApiMapping(category.id),
+			Name:       itemCategoryNames[category.id][lang],
+			Count:      category.count,
+			MinLevel:   category.minLevel,
+			MaxLevel:   category.maxLevel,
+			SuperTypes: make([]APITaxonomySuperType, 0),
+		}
+
+		for _, superType := range groupItemTypes(category.itemTypes, func(itemType *ItemTypeDbEntry) int { return itemType.SuperTypeId }) {
+			renderedSuperType := APITaxonomySuperType{
+				Id:        superType.id,
+				Count:     superType.count,
+				MinLevel:  superType.minLevel,
+				MaxLevel:  superType.maxLevel,
+				ItemTypes: make([]APITaxonomyItemType, len(superType.itemTypes)),
+			}
+			if names, ok := itemSuperTypeNames[superType.id]; ok {
+				renderedSuperType.Name = names[lang]
+			} else if len(superType.itemTypes) == 1 {
+				renderedSuperType.Name = superType.itemTypes[0].Names[lang]
+			}
+
+			for i, itemType := range superType.itemTypes {
+				renderedSuperType.ItemTypes[i] = RenderTaxonomyItemType(itemType, lang)
+			}
+			renderedCategory.SuperTypes = append(renderedCategory.SuperTypes, renderedSuperType)
+		}
+
+		categories = append(categories, renderedCategory)
+	}
+	return categories
+}
+
+func GetTaxonomy(w http.ResponseWriter, r *http.Request) {
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	itemTypes, err := itemTypeEntries(txn)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	if len(itemTypes) == 0 {
+		e.WriteNotFoundResponse(w, "No item types found.")
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, utils.RenderLocalized(r, func(lang string) any {
+		return RenderTaxonomy(itemTypes, lang)
+	}))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
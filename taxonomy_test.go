@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	mapping "github.com/dofusdude/dodumap"
+)
+
+func TestRenderTaxonomy(t *testing.T) {
+	entry := func(id int64, ankamaId int, name string, categoryId int, superTypeId int, levels ...int) *ItemTypeDbEntry {
+		itemType := newItemTypeEntry(mapping.MappedMultilangItemTypeUnity{
+			ItemTypeId:  ankamaId,
+			Name:        map[string]string{"en": name, "fr": name + " fr"},
+			CategoryId:  categoryId,
+			SuperTypeId: superTypeId,
+		})
+		itemType.ID = id
+		for _, level := range levels {
+			itemType.addItem(&mapping.MappedMultilangItemUnity{Level: level})
+		}
+		return itemType
+	}
+
+	taxonomy := RenderTaxonomy([]*ItemTypeDbEntry{
+		entry(1, 16, "Hat", 0, 10, 5, 200),
+		entry(2, 6, "Sword", 0, weaponSuperTypeId, 20),
+		entry(3, 7, "Bow", 0, weaponSuperTypeId, 1, 60),
+		entry(4, 245, "Petsmount", 0, 12, 100),
+		entry(5, 113, "Living object", 5, 22, 1),
+	}, "fr")
+
+	if len(taxonomy) != 2 || taxonomy[0].Slug != "equipment" || taxonomy[0].Name != "Équipements" || taxonomy[1].Slug != "cosmetics" {
+		t.Fatal("Expected equipment and cosmetics, got ", taxonomy)
+	}
+
+	equipment := taxonomy[0]
+	if equipment.Count != 6 || equipment.MinLevel != 1 || equipment.MaxLevel != 200 || len(equipment.SuperTypes) != 3 {
+		t.Error("Expected the sums of all equipment types, got ", equipment)
+	}
+
+	weapons := equipment.SuperTypes[0]
+	if weapons.Id != weaponSuperTypeId || weapons.Name != "Armes" || weapons.Count != 3 || weapons.MinLevel != 1 || weapons.MaxLevel != 60 {
+		t.Error("Expected the weapon super type, got ", weapons)
+	}
+	if len(weapons.ItemTypes) != 2 || !weapons.ItemTypes[0].IsWeapon || weapons.ItemTypes[0].Name != "Sword fr" {
+		t.Error("Expected the weapon types in registry order, got ", weapons.ItemTypes)
+	}
+
+	if hats := equipment.SuperTypes[1]; hats.Id != 10 || hats.Name != "Hat fr" {
+		t.Error("Expected a super type of one item type to be named after it, got ", hats)
+	}
+	if mounts := equipment.SuperTypes[2]; !mounts.ItemTypes[0].IsMount || mounts.ItemTypes[0].IsWeapon {
+		t.Error("Expected a mount type, got ", mounts)
+	}
+	if cosmetic := taxonomy[1].SuperTypes[0].ItemTypes[0]; !cosmetic.IsCosmetic || cosmetic.Count != 1 {
+		t.Error("Expected a cosmetic type, got ", cosmetic)
+	}
+}
@@ -2,6 +2,10 @@ package main
 
 import (
 	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/dofusdude/doduapi/config"
@@ -9,6 +13,7 @@ import (
 	"github.com/dofusdude/doduapi/utils"
 	mapping "github.com/dofusdude/dodumap"
 	"github.com/hashicorp/go-memdb"
+	"github.com/zyedidia/generic/set"
 )
 
 type ApiImageUrls struct {
@@ -49,15 +54,17 @@ type ApiAllSearchResultType struct {
 }
 
 type ApiAllSearchResult struct {
-	Name       string                 `json:"name"`
+	Name       string                 `json:"name" localized:"true"`
 	Id         int                    `json:"ankama_id"`
 	Type       ApiAllSearchResultType `json:"type"`
 	ItemFields *ApiAllSearchItem      `json:"item_fields,omitempty"`
 }
 
+// ApiAllSearchResultScore is a search hit before the results of all indices are ranked. Render renders it in a
+// language, so only the returned hits are rendered in every language of the all-languages mode.
 type ApiAllSearchResultScore struct {
-	Result ApiAllSearchResult `json:"result"`
-	Score  float64            `json:"score"`
+	Render func(lang string) ApiAllSearchResult
+	Score  float64
 }
 
 type ApiEffect struct {
@@ -66,7 +73,7 @@ type ApiEffect struct {
 	Type         ApiEffectType `json:"type"`
 	IgnoreMinInt bool          `json:"ignore_int_min"`
 	IgnoreMaxInt bool          `json:"ignore_int_max"`
-	Formatted    string        `json:"formatted"`
+	Formatted    string        `json:"formatted" localized:"true"`
 }
 
 func RenderEffects(effects *[]mapping.MappedMultilangEffect, lang string) []ApiEffect {
@@ -94,6 +101,39 @@ func RenderEffects(effects *[]mapping.MappedMultilangEffect, lang string) []ApiE
 	return nil
 }
 
+// effectCsvColumns gives every effect type its own min and max column, so the columns line up across items.
+func effectCsvColumns(prefix string, value reflect.Value) [][2]string {
+	effects := value.Interface().([]ApiEffect)
+
+	columns := make([][2]string, 0, len(effects)*2)
+	seen := make(map[string]int, len(effects))
+	for _, effect := range effects {
+		name := effect.Type.Name
+		if name == "" {
+			name = strconv.Itoa(effect.Type.Id)
+		}
+		seen[name]++
+		if seen[name] > 1 { // the same effect type twice on one item
+			name = fmt.Sprintf("%s (%d)", name, seen[name])
+		}
+
+		column := fmt.Sprintf("%s.%s", prefix, name)
+		var minValue, maxValue string
+		if !effect.IgnoreMinInt {
+			minValue = strconv.Itoa(effect.MinInt)
+		}
+		if !effect.IgnoreMaxInt {
+			maxValue = strconv.Itoa(effect.MaxInt)
+		}
+		columns = append(columns, [2]string{column + ".min", minValue}, [2]string{column + ".max", maxValue})
+	}
+	return columns
+}
+
+func init() {
+	utils.RegisterCsvSliceColumns(reflect.TypeOf(ApiEffect{}), effectCsvColumns)
+}
+
 type ApiCondition struct {
 	Operator string           `json:"operator"`
 	IntValue int              `json:"int_value"`
@@ -109,8 +149,8 @@ type ApiConditionNode struct {
 
 type APIResource struct {
 	Id          int               `json:"ankama_id"`
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
+	Name        string            `json:"name" localized:"true"`
+	Description string            `json:"description" localized:"true"`
 	Type        ApiType           `json:"type"`
 	Level       int               `json:"level"`
 	Pods        int               `json:"pods"`
@@ -149,8 +189,8 @@ func RenderResource(item *mapping.MappedMultilangItemUnity, lang string) APIReso
 
 type APIEquipment struct {
 	Id          int                `json:"ankama_id"`
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
+	Name        string             `json:"name" localized:"true"`
+	Description string             `json:"description" localized:"true"`
 	Type        ApiType            `json:"type"`
 	IsWeapon    bool               `json:"is_weapon"`
 	Level       int                `json:"level"`
@@ -205,13 +245,13 @@ type APIRange struct {
 
 type APISetReverseLink struct {
 	Id   int    `json:"id"`
-	Name string `json:"name"`
+	Name string `json:"name" localized:"true"`
 }
 
 type APIWeapon struct {
 	Id                     int                `json:"ankama_id"`
-	Name                   string             `json:"name"`
-	Description            string             `json:"description"`
+	Name                   string             `json:"name" localized:"true"`
+	Description            string             `json:"description" localized:"true"`
 	Type                   ApiType            `json:"type"`
 	IsWeapon               bool               `json:"is_weapon"`
 	Level                  int                `json:"level"`
@@ -224,6 +264,7 @@ type APIWeapon struct {
 	MaxCastPerTurn         int                `json:"max_cast_per_turn"`
 	ApCost                 int                `json:"ap_cost"`
 	Range                  APIRange           `json:"range"`
+	TwoHanded              bool               `json:"two_handed"`
 	Recipe                 []APIRecipe        `json:"recipe,omitempty"`
 	ParentSet              *APISetReverseLink `json:"parent_set,omitempty"`
 }
@@ -257,6 +298,7 @@ func RenderWeapon(item *mapping.MappedMultilangItemUnity, lang string) APIWeapon
 			Min: item.MinRange,
 			Max: item.Range,
 		},
+		TwoHanded: twoHandedWeaponTypeIds[item.Type.ItemTypeId],
 		IsWeapon:  true,
 		ParentSet: setLink,
 	}
@@ -339,17 +381,17 @@ func RenderConditions(conditions *[]mapping.MappedMultilangCondition, lang strin
 }
 
 type ApiType struct {
-	Name string `json:"name"`
+	Name string `json:"name" localized:"true"`
 	Id   int    `json:"id"`
 }
 
 type ApiConditionType struct {
-	Name string `json:"name"`
+	Name string `json:"name" localized:"true"`
 	Id   int    `json:"id"`
 }
 
 type ApiEffectType struct {
-	Name     string `json:"name"`
+	Name     string `json:"name" localized:"true"`
 	Id       int    `json:"id"`
 	IsMeta   bool   `json:"is_meta"`
 	IsActive bool   `json:"is_active"`
@@ -357,13 +399,13 @@ type ApiEffectType struct {
 
 type APIListItem struct {
 	Id        int          `json:"ankama_id"`
-	Name      string       `json:"name"`
+	Name      string       `json:"name" localized:"true"`
 	Type      ApiType      `json:"type"`
 	Level     int          `json:"level"`
 	ImageUrls ApiImageUrls `json:"image_urls,omitempty"`
 
 	// extra fields
-	Description *string           `json:"description,omitempty"`
+	Description *string           `json:"description,omitempty" localized:"true"`
 	Recipe      []APIRecipe       `json:"recipe,omitempty"`
 	Conditions  *ApiConditionNode `json:"conditions,omitempty"`
 	Effects     []ApiEffect       `json:"effects,omitempty"`
@@ -394,6 +436,87 @@ func RenderItemListEntry(item *mapping.MappedMultilangItemUnity, lang string) AP
 	}
 }
 
+// RenderItemListEntryExpanded renders a list entry with the extra fields requested through fields[item].
+func RenderItemListEntryExpanded(p *mapping.MappedMultilangItemUnity, lang string, expansions *set.Set[string], txn *memdb.Txn) APIListItem {
+	item := RenderItemListEntry(p, lang)
+	// items extra fields
+	if expansions.Has("recipe") {
+		recipe, exists := GetRecipeIfExists(item.Id, txn)
+		if exists {
+			item.Recipe = RenderRecipe(recipe, database.Db)
+		} else {
+			item.Recipe = nil
+		}
+	}
+
+	if expansions.Has("description") {
+		description := p.Description[lang]
+		item.Description = &description
+	}
+
+	if expansions.Has("conditions") {
+		if p.Conditions != nil {
+			item.Conditions = RenderConditionTree(p.Conditions, lang)
+		}
+	}
+
+	if expansions.Has("effects") {
+		if p.Effects != nil {
+			renderedEffects := RenderEffects(&p.Effects, lang)
+			if len(renderedEffects) != 0 {
+				item.Effects = renderedEffects
+			}
+		}
+	}
+
+	if expansions.Has("pods") {
+		item.Pods = &p.Pods
+	}
+
+	// equipment extra fields
+	mIsWeapon := p.Type.SuperTypeId == 2 // is weapon
+	if expansions.Has("is_weapon") {
+		item.IsWeapon = &mIsWeapon
+	}
+
+	if expansions.Has("parent_set") {
+		if p.HasParentSet {
+			item.ParentSet = &APISetReverseLink{
+				Id:   p.ParentSet.Id,
+				Name: p.ParentSet.Name[lang],
+			}
+		}
+	}
+
+	// weapon extra fields
+	if mIsWeapon {
+		if expansions.Has("critical_hit_probability") {
+			item.CriticalHitProbability = &p.CriticalHitProbability
+		}
+
+		if expansions.Has("critical_hit_bonus") {
+			item.CriticalHitBonus = &p.CriticalHitBonus
+		}
+
+		if expansions.Has("max_cast_per_turn") {
+			item.MaxCastPerTurn = &p.MaxCastPerTurn
+		}
+
+		if expansions.Has("ap_cost") {
+			item.ApCost = &p.ApCost
+		}
+
+		if expansions.Has("range") {
+			item.Range = &APIRange{
+				Min: p.MinRange,
+				Max: p.Range,
+			}
+		}
+	}
+
+	return item
+}
+
 type APIListItemType struct {
 	Id     int    `json:"ankama_id"`
 	NameId string `json:"name_id"` // not translated
@@ -401,7 +524,7 @@ type APIListItemType struct {
 
 type APIListTypedItem struct {
 	Id          int             `json:"ankama_id"`
-	Name        string          `json:"name"`
+	Name        string          `json:"name" localized:"true"`
 	Type        ApiType         `json:"type"`
 	ItemSubtype APIListItemType `json:"item_subtype"`
 	Level       int             `json:"level"`
@@ -425,6 +548,40 @@ func RenderTypedItemListEntry(item *mapping.MappedMultilangItemUnity, lang strin
 	}
 }
 
+// APITypedItem wraps a single item of any category together with the category it belongs to.
+type APITypedItem struct {
+	ItemSubtype APIListItemType `json:"item_subtype"`
+	Item        any             `json:"item"`
+}
+
+// RenderSingleItem renders an item like the single endpoint of its category, including the recipe.
+func RenderSingleItem(item *mapping.MappedMultilangItemUnity, lang string, txn *memdb.Txn) any {
+	recipe, hasRecipe := GetRecipeIfExists(item.AnkamaId, txn)
+
+	switch utils.CategoryIdMapping(item.Type.CategoryId) {
+	case "equipment", "cosmetics":
+		if item.Type.SuperTypeId == 2 { // is weapon
+			weapon := RenderWeapon(item, lang)
+			if hasRecipe {
+				weapon.Recipe = RenderRecipe(recipe, database.Db)
+			}
+			return weapon
+		}
+
+		equipment := RenderEquipment(item, lang)
+		if hasRecipe {
+			equipment.Recipe = RenderRecipe(recipe, database.Db)
+		}
+		return equipment
+	default:
+		resource := RenderResource(item, lang)
+		if hasRecipe {
+			resource.Recipe = RenderRecipe(recipe, database.Db)
+		}
+		return resource
+	}
+}
+
 type APIRecipe struct {
 	AnkamaId int    `json:"item_ankama_id"`
 	ItemType string `json:"item_subtype"`
@@ -462,24 +619,61 @@ type APIPageItem struct {
 	Items []APIListItem         `json:"items"`
 }
 
+func (p APIPageItem) ListEntries() any {
+	return p.Items
+}
+
+// APIEnvelope wraps a response when related entities or facets are requested.
+type APIEnvelope struct {
+	Data     any                  `json:"data"`
+	Included *[]APIIncludedEntity `json:"included,omitempty"`
+	Facets   any                  `json:"facets,omitempty"`
+}
+
+// wrapResponse puts a rendered response into an envelope next to its included entities and facets. Without both the
+// response stays as it is.
+func wrapResponse(r *http.Request, data any, included *includer, facets *facetCounts) any {
+	if included == nil && facets == nil {
+		return data
+	}
+
+	envelope := APIEnvelope{Data: data}
+	if included != nil {
+		entities := included.render(r)
+		envelope.Included = &entities
+	}
+	if facets != nil {
+		envelope.Facets = utils.RenderLocalized(r, facets.render)
+	}
+	return envelope
+}
+
 type APIPageMount struct {
 	Links utils.PaginationLinks `json:"_links,omitempty"`
 	Items []APIMount            `json:"mounts"`
 }
 
+func (p APIPageMount) ListEntries() any {
+	return p.Items
+}
+
 type APIPageSet struct {
 	Links utils.PaginationLinks `json:"_links,omitempty"`
 	Items []APIListSet          `json:"sets"`
 }
 
+func (p APIPageSet) ListEntries() any {
+	return p.Items
+}
+
 type APIMountFamily struct {
 	Id   int    `json:"ankama_id"`
-	Name string `json:"name"`
+	Name string `json:"name" localized:"true"`
 }
 
 type APIMount struct {
 	Id        int            `json:"ankama_id"`
-	Name      string         `json:"name"`
+	Name      string         `json:"name" localized:"true"`
 	Family    APIMountFamily `json:"family"`
 	ImageUrls ApiImageUrls   `json:"image_urls,omitempty"`
 	Effects   []ApiEffect    `json:"effects,omitempty"`
@@ -497,6 +691,18 @@ func RenderEquipmentAsMountListEntry(item *mapping.MappedMultilangItemUnity, lan
 	}
 }
 
+// RenderMountListEntryExpanded renders a list entry with the extra fields requested through fields[mount].
+func RenderMountListEntryExpanded(item *mapping.MappedMultilangItemUnity, lang string, expansions *set.Set[string]) APIMount {
+	mount := RenderEquipmentAsMountListEntry(item, lang)
+	if expansions.Has("effects") {
+		effects := RenderEffects(&item.Effects, lang)
+		if len(effects) != 0 {
+			mount.Effects = effects
+		}
+	}
+	return mount
+}
+
 func RenderEquipmentAsMount(item *mapping.MappedMultilangItemUnity, lang string) APIMount {
 	resMount := RenderEquipmentAsMountListEntry(item, lang)
 	effects := RenderEffects(&item.Effects, lang)
@@ -508,7 +714,7 @@ func RenderEquipmentAsMount(item *mapping.MappedMultilangItemUnity, lang string)
 
 type APIListSet struct {
 	Id                    int    `json:"ankama_id"`
-	Name                  string `json:"name"`
+	Name                  string `json:"name" localized:"true"`
 	Items                 int    `json:"items"`
 	Level                 int    `json:"level"`
 	ContainsCosmetics     bool   `json:"contains_cosmetics"`
@@ -530,9 +736,27 @@ func RenderSetListEntry(set *mapping.MappedMultilangSetUnity, lang string) APILi
 	}
 }
 
+// RenderSetListEntryExpanded renders a list entry with the extra fields requested through fields[set].
+func RenderSetListEntryExpanded(p *mapping.MappedMultilangSetUnity, lang string, expansions *set.Set[string]) APIListSet {
+	set := RenderSetListEntry(p, lang)
+
+	if expansions.Has("effects") {
+		set.Effects = make(map[int][]ApiEffect, 0)
+		for itemCombination, effect := range p.Effects {
+			set.Effects[itemCombination] = RenderEffects(&effect, lang)
+		}
+	}
+
+	if expansions.Has("equipment_ids") {
+		set.ItemIds = p.ItemIds
+	}
+
+	return set
+}
+
 type APISet struct {
 	AnkamaId              int                 `json:"ankama_id"`
-	Name                  string              `json:"name"`
+	Name                  string              `json:"name" localized:"true"`
 	ItemIds               []int               `json:"equipment_ids"`
 	Effects               map[int][]ApiEffect `json:"effects,omitempty"`
 	Level                 int                 `json:"highest_equipment_level"`
@@ -564,3 +788,25 @@ func RenderSet(set *mapping.MappedMultilangSetUnity, lang string) APISet {
 
 	return resSet
 }
+
+type APIKeyInfo struct {
+	Prefix          string    `json:"prefix"`
+	Owner           string    `json:"owner"`
+	CreatedAt       time.Time `json:"created_at"`
+	RateLimitFactor float64   `json:"rate_limit_factor"`
+}
+
+type APIKeyUsageEntry struct {
+	Day      string `json:"day"`
+	Route    string `json:"route"`
+	Requests int64  `json:"requests"`
+}
+
+type APIKeyUsage struct {
+	Key   APIKeyInfo         `json:"key"`
+	Usage []APIKeyUsageEntry `json:"usage"`
+}
+
+func (u APIKeyUsage) ListEntries() any {
+	return u.Usage
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Cursor is the opaque position of a crawl through a sorted listing.
+type Cursor struct {
+	Generation int64  `json:"g"`
+	Sort       string `json:"s,omitempty"`
+	LastId     int    `json:"id"`
+}
+
+// CursorPagination is set instead of page[number] when the client uses page[after]. After is nil for the first page.
+type CursorPagination struct {
+	After    *Cursor
+	PageSize int
+}
+
+func EncodeCursor(cursor Cursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	var cursor Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, fmt.Errorf("cursor is not valid")
+	}
+
+	if err = json.Unmarshal(raw, &cursor); err != nil {
+		return cursor, fmt.Errorf("cursor is not valid")
+	}
+
+	return cursor, nil
+}
+
+// BuildCursorLinks keeps all query parameters and only replaces page[after]. Cursors can only move forward.
+func BuildCursorLinks(mainUrl url.URL, nextCursor *Cursor, pageSize int, apiScheme string, apiHostname string) PaginationLinks {
+	baseUrl, _ := url.JoinPath(fmt.Sprintf("%s://%s", apiScheme, apiHostname), mainUrl.Path)
+
+	query := mainUrl.Query()
+	query.Del("page[number]")
+	query.Set("page[size]", fmt.Sprintf("%d", pageSize))
+
+	query.Set("page[after]", "")
+	firstUrl := fmt.Sprintf("%s?%s", baseUrl, query.Encode())
+
+	var finalNextUrl *string
+	if nextCursor != nil {
+		query.Set("page[after]", EncodeCursor(*nextCursor))
+		nextUrl := fmt.Sprintf("%s?%s", baseUrl, query.Encode())
+		finalNextUrl = &nextUrl
+	}
+
+	return PaginationLinks{
+		First: &firstUrl,
+		Next:  finalNextUrl,
+	}
+}
@@ -0,0 +1,285 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	FormatJson    = "json"
+	FormatCsv     = "csv"
+	FormatNdjson  = "ndjson"
+	FormatMsgpack = "msgpack"
+)
+
+var Formats = []string{FormatJson, FormatCsv, FormatNdjson, FormatMsgpack}
+
+var formatContentTypes = map[string]string{
+	FormatJson:    "application/json",
+	FormatCsv:     "text/csv; charset=utf-8",
+	FormatNdjson:  "application/x-ndjson",
+	FormatMsgpack: "application/msgpack",
+}
+
+var mediaTypeFormats = map[string]string{
+	"application/json":        FormatJson,
+	"application/*":           FormatJson,
+	"*/*":                     FormatJson,
+	"text/csv":                FormatCsv,
+	"text/*":                  FormatCsv,
+	"application/x-ndjson":    FormatNdjson,
+	"application/ndjson":      FormatNdjson,
+	"application/jsonl":       FormatNdjson,
+	"application/msgpack":     FormatMsgpack,
+	"application/x-msgpack":   FormatMsgpack,
+	"application/vnd.msgpack": FormatMsgpack,
+}
+
+// ListResponse is implemented by responses that wrap a list, like pages. CSV and NDJSON only write the entries.
+type ListResponse interface {
+	ListEntries() any
+}
+
+// CsvColumns flattens a slice into named columns. It is used for slices where the position has no meaning, so
+// the same value ends up in the same column for every row.
+type CsvColumns func(prefix string, value reflect.Value) [][2]string
+
+var csvSliceColumns = map[reflect.Type]CsvColumns{}
+
+// RegisterCsvSliceColumns sets how slices of the given element type are flattened into CSV columns.
+func RegisterCsvSliceColumns(elem reflect.Type, columns CsvColumns) {
+	csvSliceColumns[elem] = columns
+}
+
+// NegotiateFormat picks the response format from the format query parameter or else the Accept header.
+func NegotiateFormat(r *http.Request) (string, error) {
+	if format := strings.ToLower(r.URL.Query().Get("format")); format != "" {
+		for _, known := range Formats {
+			if format == known {
+				return format, nil
+			}
+		}
+		return "", fmt.Errorf("unknown format %s, use one of %s", format, strings.Join(Formats, ", "))
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return FormatJson, nil
+	}
+
+	for _, mediaType := range qualityValues(accept) {
+		if format, ok := mediaTypeFormats[mediaType]; ok {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("none of %s can be produced, use one of %s", accept, strings.Join(Formats, ", "))
+}
+
+// qualityValues returns the lowercased values of a header like Accept or Accept-Language, ordered by their q
+// parameter. Values with q=0 are not acceptable and left out.
+func qualityValues(header string) []string {
+	type qualityValue struct {
+		value   string
+		quality float64
+	}
+
+	var values []qualityValue
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		current := qualityValue{value: strings.ToLower(strings.TrimSpace(params[0])), quality: 1}
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if key == "q" {
+				if quality, err := strconv.ParseFloat(value, 64); err == nil {
+					current.quality = quality
+				}
+			}
+		}
+		if current.quality > 0 && current.value != "" {
+			values = append(values, current)
+		}
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].quality > values[j].quality
+	})
+
+	result := make([]string, len(values))
+	for i, current := range values {
+		result[i] = current.value
+	}
+	return result
+}
+
+// WriteData encodes the data in the format negotiated for the request, JSON if there was no negotiation.
+func WriteData(w http.ResponseWriter, r *http.Request, data any) error {
+	format, ok := r.Context().Value("format").(string)
+	if !ok {
+		format = FormatJson
+	}
+
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", formatContentTypes[format])
+
+	switch format {
+	case FormatCsv:
+		return writeCsv(w, data)
+	case FormatNdjson:
+		encoder := json.NewEncoder(w)
+		for _, entry := range listEntries(data) {
+			if err := encoder.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatMsgpack:
+		encoder := msgpack.NewEncoder(w)
+		encoder.SetCustomStructTag("json")
+		return encoder.Encode(data)
+	default:
+		return json.NewEncoder(w).Encode(data)
+	}
+}
+
+// listEntries returns the rows of a response. Single entities are one row.
+func listEntries(data any) []any {
+	if list, ok := data.(ListResponse); ok {
+		data = list.ListEntries()
+	}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return []any{data}
+	}
+
+	entries := make([]any, value.Len())
+	for i := range entries {
+		entries[i] = value.Index(i).Interface()
+	}
+	return entries
+}
+
+func writeCsv(w http.ResponseWriter, data any) error {
+	var header []string
+	columnIndex := map[string]int{}
+	var rows []map[string]string
+
+	for _, entry := range listEntries(data) {
+		row := map[string]string{}
+		for _, column := range csvFlatten("", reflect.ValueOf(entry)) {
+			if column[0] == "" { // lists of plain values
+				column[0] = "value"
+			}
+			if _, exists := columnIndex[column[0]]; !exists {
+				columnIndex[column[0]] = len(header)
+				header = append(header, column[0])
+			}
+			row[column[0]] = column[1]
+		}
+		rows = append(rows, row)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range rows {
+		for i, column := range header {
+			record[i] = row[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvJoin(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// csvFlatten turns nested values into dotted column names, for example type.name or image_urls.icon.
+func csvFlatten(prefix string, value reflect.Value) [][2]string {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		return nil
+	}
+
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		return [][2]string{{prefix, value.Interface().(time.Time).Format(time.RFC3339)}}
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		var columns [][2]string
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			columns = append(columns, csvFlatten(csvJoin(prefix, name), value.Field(i))...)
+		}
+		return columns
+	case reflect.Slice, reflect.Array:
+		if columns, ok := csvSliceColumns[value.Type().Elem()]; ok {
+			return columns(prefix, value)
+		}
+
+		elemKind := value.Type().Elem().Kind()
+		if elemKind != reflect.Struct && elemKind != reflect.Pointer && elemKind != reflect.Slice && elemKind != reflect.Map {
+			values := make([]string, value.Len())
+			for i := range values {
+				values[i] = fmt.Sprint(value.Index(i).Interface())
+			}
+			return [][2]string{{prefix, strings.Join(values, "|")}}
+		}
+
+		var columns [][2]string
+		for i := 0; i < value.Len(); i++ {
+			columns = append(columns, csvFlatten(csvJoin(prefix, strconv.Itoa(i)), value.Index(i))...)
+		}
+		return columns
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		var columns [][2]string
+		for _, key := range keys {
+			columns = append(columns, csvFlatten(csvJoin(prefix, fmt.Sprint(key.Interface())), value.MapIndex(key))...)
+		}
+		return columns
+	default:
+		return [][2]string{{prefix, fmt.Sprint(value.Interface())}}
+	}
+}
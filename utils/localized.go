@@ -0,0 +1,447 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// LocalizedTag marks struct fields with translated text. In the all-languages mode they become maps from the
+// language to the text, for example `json:"name" localized:"true"`.
+const LocalizedTag = "localized"
+
+// RequestLanguages returns the languages a response is rendered in and whether the renders are merged with
+// Localize. It is the single request language unless the request used the all-languages mode.
+func RequestLanguages(r *http.Request) ([]string, bool) {
+	if langs, ok := r.Context().Value("langs").([]string); ok {
+		return langs, true
+	}
+	return []string{r.Context().Value("lang").(string)}, false
+}
+
+// NegotiateLanguage picks the first of the languages that an Accept-Language header accepts. Regional ranges like
+// pt-BR match their language. It returns false if the header accepts none of them or any language with *.
+func NegotiateLanguage(acceptLanguage string, languages []string) (string, bool) {
+	for _, languageRange := range qualityValues(acceptLanguage) {
+		if languageRange == "*" {
+			return "", false
+		}
+		primary, _, _ := strings.Cut(languageRange, "-")
+		for _, lang := range languages {
+			if lang == languageRange || lang == primary {
+				return lang, true
+			}
+		}
+	}
+	return "", false
+}
+
+// FallbackLanguages returns the languages that fill in missing translations of the request language, in order.
+func FallbackLanguages(r *http.Request) []string {
+	fallback, _ := r.Context().Value("fallback").([]string)
+	return fallback
+}
+
+// RenderLocalized renders the response in the request language. In the all-languages mode it renders once per
+// language and merges the renders with Localize.
+func RenderLocalized(r *http.Request, render func(lang string) any) any {
+	langs, localized := RequestLanguages(r)
+	if !localized {
+		return Fallback(r, render(langs[0]), render)
+	}
+
+	renders := make([]any, len(langs))
+	for i, lang := range langs {
+		renders[i] = render(lang)
+	}
+	return Localize(renders, langs)
+}
+
+// localizedList keeps the entries of a merged list response, so CSV and NDJSON still write one row per entry.
+type localizedList struct {
+	Object  map[string]any
+	Entries any
+}
+
+func (l localizedList) ListEntries() any {
+	return l.Entries
+}
+
+func (l localizedList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Object)
+}
+
+func (l localizedList) EncodeMsgpack(encoder *msgpack.Encoder) error {
+	return encoder.Encode(l.Object)
+}
+
+// Localize merges renders of the same response in the given languages, one render per language in the same order.
+// Fields tagged as localized become language maps, all others are taken from the first render.
+func Localize(renders []any, langs []string) any {
+	values := make([]reflect.Value, len(renders))
+	for i, render := range renders {
+		values[i] = reflect.ValueOf(render)
+	}
+
+	merged := localize(values, langs)
+	if _, ok := renders[0].(ListResponse); !ok {
+		return merged
+	}
+
+	entries := make([]any, len(renders))
+	for i, render := range renders {
+		entries[i] = render.(ListResponse).ListEntries()
+	}
+	object, _ := merged.(map[string]any)
+	return localizedList{Object: object, Entries: Localize(entries, langs)}
+}
+
+// Fallback fills localized fields that are empty in the request language with the first fallback language that has
+// a text. Objects with such fields get a _fallback map from the field to the language of its text. Responses without
+// missing translations are returned unchanged.
+func Fallback(r *http.Request, rendered any, render func(lang string) any) any {
+	fallbackLangs := FallbackLanguages(r)
+	if len(fallbackLangs) == 0 || !missingTranslation(reflect.ValueOf(rendered)) {
+		return rendered
+	}
+
+	renders := []any{rendered}
+	langs := []string{r.Context().Value("lang").(string)}
+	for _, lang := range fallbackLangs {
+		renders = append(renders, render(lang))
+		langs = append(langs, lang)
+	}
+
+	values := make([]reflect.Value, len(renders))
+	for i, render := range renders {
+		values[i] = reflect.ValueOf(render)
+	}
+	if !fillable(values) {
+		return rendered
+	}
+
+	filled := fillFallback(values, langs)
+	object, ok := filled.(map[string]any)
+	if _, isList := rendered.(ListResponse); !isList || !ok {
+		return filled
+	}
+
+	entries := make([]reflect.Value, len(renders))
+	for i, render := range renders {
+		entries[i] = reflect.ValueOf(render.(ListResponse).ListEntries())
+	}
+	return localizedList{Object: object, Entries: fillFallback(entries, langs)}
+}
+
+// indirect follows pointers and interfaces. Nil values become the invalid reflect.Value.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// jsonFields calls field for the exported fields of a struct type that encoding/json writes, with their json name.
+func jsonFields(t reflect.Type, field func(index int, structField reflect.StructField, name string, omitempty bool)) {
+	for f := 0; f < t.NumField(); f++ {
+		structField := t.Field(f)
+		if !structField.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+		field(f, structField, name, strings.Contains(options, "omitempty"))
+	}
+}
+
+// missingTranslation reports whether a localized field somewhere in the value is empty.
+func missingTranslation(value reflect.Value) bool {
+	value = indirect(value)
+	if !value.IsValid() || value.Type() == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+
+	missing := false
+	switch value.Kind() {
+	case reflect.Struct:
+		jsonFields(value.Type(), func(f int, structField reflect.StructField, _ string, _ bool) {
+			if missing {
+				return
+			}
+			field := indirect(value.Field(f))
+			if structField.Tag.Get(LocalizedTag) == "true" && (!field.IsValid() || isEmptyValue(field)) {
+				missing = true
+				return
+			}
+			missing = missingTranslation(field)
+		})
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len() && !missing; i++ {
+			missing = missingTranslation(value.Index(i))
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() && !missing {
+			missing = missingTranslation(iter.Value())
+		}
+	}
+	return missing
+}
+
+// fillable reports whether a localized field that is empty in the first value has a text in one of the others.
+// The values are renders of the same response in different languages.
+func fillable(values []reflect.Value) bool {
+	for i := range values {
+		values[i] = indirect(values[i])
+	}
+
+	first := values[0]
+	if !first.IsValid() || first.Type() == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	for _, value := range values[1:] {
+		if !value.IsValid() || value.Type() != first.Type() {
+			return false
+		}
+	}
+
+	switch first.Kind() {
+	case reflect.Struct:
+		result := false
+		jsonFields(first.Type(), func(f int, structField reflect.StructField, _ string, _ bool) {
+			if result {
+				return
+			}
+			fields := make([]reflect.Value, len(values))
+			for i, value := range values {
+				fields[i] = value.Field(f)
+			}
+			if structField.Tag.Get(LocalizedTag) == "true" && fallbackIndex(fields) > 0 {
+				result = true
+				return
+			}
+			result = fillable(fields)
+		})
+		return result
+	case reflect.Slice, reflect.Array:
+		for _, value := range values[1:] {
+			if value.Len() != first.Len() {
+				return false
+			}
+		}
+		for e := 0; e < first.Len(); e++ {
+			elements := make([]reflect.Value, len(values))
+			for i, value := range values {
+				elements[i] = value.Index(e)
+			}
+			if fillable(elements) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := first.MapRange()
+		for iter.Next() {
+			entries := make([]reflect.Value, len(values))
+			for i, value := range values {
+				entries[i] = value.MapIndex(iter.Key())
+			}
+			if fillable(entries) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fallbackIndex returns the index of the first value with a text if the first value is empty, else 0.
+func fallbackIndex(fields []reflect.Value) int {
+	if first := indirect(fields[0]); first.IsValid() && !isEmptyValue(first) {
+		return 0
+	}
+	for i, field := range fields[1:] {
+		if field = indirect(field); field.IsValid() && !isEmptyValue(field) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// fillFallback merges renders in the request language and its fallback languages like localize does. Only the parts
+// with filled in texts become maps, everything else keeps its type.
+func fillFallback(values []reflect.Value, langs []string) any {
+	if !fillable(values) {
+		if !values[0].IsValid() {
+			return nil
+		}
+		return values[0].Interface()
+	}
+	first := values[0]
+
+	switch first.Kind() {
+	case reflect.Struct:
+		object := make(map[string]any)
+		fallbacks := make(map[string]string)
+		jsonFields(first.Type(), func(f int, structField reflect.StructField, name string, omitempty bool) {
+			fields := make([]reflect.Value, len(values))
+			for i, value := range values {
+				fields[i] = value.Field(f)
+			}
+			if structField.Tag.Get(LocalizedTag) == "true" {
+				if i := fallbackIndex(fields); i > 0 {
+					object[name] = fields[i].Interface()
+					fallbacks[name] = langs[i]
+					return
+				}
+			}
+			if omitempty && isEmptyValue(fields[0]) {
+				return
+			}
+			object[name] = fillFallback(fields, langs)
+		})
+		if len(fallbacks) > 0 {
+			object["_fallback"] = fallbacks
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		merged := make([]any, first.Len())
+		for e := range merged {
+			elements := make([]reflect.Value, len(values))
+			for i, value := range values {
+				elements[i] = value.Index(e)
+			}
+			merged[e] = fillFallback(elements, langs)
+		}
+		return merged
+	case reflect.Map:
+		merged := reflect.MakeMapWithSize(reflect.MapOf(first.Type().Key(), reflect.TypeOf((*any)(nil)).Elem()), first.Len())
+		iter := first.MapRange()
+		for iter.Next() {
+			entries := make([]reflect.Value, len(values))
+			for i, value := range values {
+				entries[i] = value.MapIndex(iter.Key())
+			}
+			if result := fillFallback(entries, langs); result != nil {
+				merged.SetMapIndex(iter.Key(), reflect.ValueOf(result))
+			} else {
+				merged.SetMapIndex(iter.Key(), reflect.Zero(merged.Type().Elem()))
+			}
+		}
+		return merged.Interface()
+	default:
+		return first.Interface()
+	}
+}
+
+func localize(values []reflect.Value, langs []string) any {
+	for i := range values {
+		for values[i].Kind() == reflect.Pointer || values[i].Kind() == reflect.Interface {
+			if values[i].IsNil() {
+				return nil
+			}
+			values[i] = values[i].Elem()
+		}
+	}
+
+	first := values[0]
+	if !first.IsValid() {
+		return nil
+	}
+	for _, value := range values[1:] {
+		if !value.IsValid() || value.Type() != first.Type() {
+			return first.Interface()
+		}
+	}
+
+	if first.Type() == reflect.TypeOf(time.Time{}) {
+		return first.Interface()
+	}
+
+	switch first.Kind() {
+	case reflect.Struct:
+		object := make(map[string]any)
+		jsonFields(first.Type(), func(f int, structField reflect.StructField, name string, omitempty bool) {
+			fields := make([]reflect.Value, len(values))
+			empty := true
+			for i, value := range values {
+				fields[i] = value.Field(f)
+				empty = empty && isEmptyValue(fields[i])
+			}
+			if empty && omitempty {
+				return
+			}
+
+			if structField.Tag.Get(LocalizedTag) == "true" {
+				translations := make(map[string]any, len(langs))
+				for i, lang := range langs {
+					translations[lang] = localize([]reflect.Value{fields[i]}, langs)
+				}
+				object[name] = translations
+			} else {
+				object[name] = localize(fields, langs)
+			}
+		})
+		return object
+	case reflect.Slice, reflect.Array:
+		if first.Kind() == reflect.Slice && first.IsNil() {
+			return first.Interface()
+		}
+		for _, value := range values[1:] {
+			if value.Len() != first.Len() {
+				return first.Interface()
+			}
+		}
+		merged := make([]any, first.Len())
+		for e := range merged {
+			elements := make([]reflect.Value, len(values))
+			for i, value := range values {
+				elements[i] = value.Index(e)
+			}
+			merged[e] = localize(elements, langs)
+		}
+		return merged
+	case reflect.Map:
+		if first.IsNil() {
+			return first.Interface()
+		}
+		merged := reflect.MakeMapWithSize(reflect.MapOf(first.Type().Key(), reflect.TypeOf((*any)(nil)).Elem()), first.Len())
+		iter := first.MapRange()
+		for iter.Next() {
+			entries := make([]reflect.Value, len(values))
+			for i, value := range values {
+				entries[i] = value.MapIndex(iter.Key())
+			}
+			if result := localize(entries, langs); result != nil {
+				merged.SetMapIndex(iter.Key(), reflect.ValueOf(result))
+			} else {
+				merged.SetMapIndex(iter.Key(), reflect.Zero(merged.Type().Elem()))
+			}
+		}
+		return merged.Interface()
+	default:
+		return first.Interface()
+	}
+}
+
+// isEmptyValue reports whether encoding/json leaves out the value of an omitempty field.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return value.IsZero()
+	}
+}
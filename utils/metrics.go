@@ -5,6 +5,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// The per endpoint counters are incremented by the handlers. Responses from the response cache and 304 Not Modified
+// answers skip the handlers, so they only count in RequestsTotal and, for the cache, ResponseCacheHits.
 var (
 	RequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "dofus_requestsTotal",
@@ -51,6 +53,26 @@ var (
 		Help: "The total number of single item requests",
 	})
 
+	RequestsItemsConditions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsItemsConditions",
+		Help: "The total number of item condition evaluation requests",
+	})
+
+	RequestsBatch = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsBatch",
+		Help: "The total number of batch requests",
+	})
+
+	RequestsGraphql = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsGraphql",
+		Help: "The total number of GraphQL requests",
+	})
+
+	RequestsGrpc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsGrpc",
+		Help: "The total number of gRPC calls",
+	})
+
 	RequestsMountsSingle = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "dofus_requestsAllMountsSingle",
 		Help: "The total number of single mount requests",
@@ -70,4 +92,29 @@ var (
 		Name: "dofus_requestsAlmanaxRange",
 		Help: "The total number of almanax range requests",
 	})
+
+	ResponseCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_responseCacheHits",
+		Help: "The total number of responses served from the rendered response cache",
+	})
+
+	ResponseCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_responseCacheMisses",
+		Help: "The total number of responses that had to be rendered",
+	})
+
+	ResponseCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_responseCacheEvictions",
+		Help: "The total number of responses evicted from the rendered response cache to make room",
+	})
+
+	ResponseCacheBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "dofus_responseCacheBytes",
+		Help: "The size of the rendered response cache in bytes",
+	})
+
+	RateLimitDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dofus_rateLimitDecisions",
+		Help: "The total number of rate limited requests by route group and decision (allowed or limited)",
+	}, []string{"group", "decision"})
 )
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CachedResponse is a rendered response body together with the headers the handler set.
+type CachedResponse struct {
+	ContentType string
+	Body        []byte
+}
+
+type responseCacheEntry struct {
+	key      string
+	response CachedResponse
+}
+
+// ResponseCache keeps rendered responses in memory up to a size in bytes and evicts the least recently used ones
+// first. Callers put the data generation into the keys, so entries of old data are never served.
+type ResponseCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	bytes    int
+	lru      *list.List
+	entries  map[string]*list.Element
+}
+
+func NewResponseCache(maxBytes int) *ResponseCache {
+	return &ResponseCache{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// MaxEntrySize is the largest body that is cached. Bigger responses would evict too much of the cache at once.
+func (c *ResponseCache) MaxEntrySize() int {
+	return c.maxBytes / 4
+}
+
+func (c *ResponseCache) Get(key string) (CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		ResponseCacheMisses.Inc()
+		return CachedResponse{}, false
+	}
+
+	ResponseCacheHits.Inc()
+	c.lru.MoveToFront(element)
+	return element.Value.(*responseCacheEntry).response, true
+}
+
+func (c *ResponseCache) Put(key string, response CachedResponse) {
+	size := len(key) + len(response.Body)
+	if size > c.MaxEntrySize() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	for c.bytes+size > c.maxBytes && c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+		ResponseCacheEvictions.Inc()
+	}
+
+	c.entries[key] = c.lru.PushFront(&responseCacheEntry{key: key, response: response})
+	c.bytes += size
+	ResponseCacheBytes.Set(float64(c.bytes))
+}
+
+// Purge drops all entries, for example after the data was switched to a new version.
+func (c *ResponseCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+	c.bytes = 0
+	ResponseCacheBytes.Set(0)
+}
+
+func (c *ResponseCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*responseCacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= len(entry.key) + len(entry.response.Body)
+	ResponseCacheBytes.Set(float64(c.bytes))
+}
@@ -10,10 +10,7 @@ func SetJsonHeader(w *http.ResponseWriter) {
 }
 
 func WriteCacheHeader(w *http.ResponseWriter) {
-	SetJsonHeader(w)
-	//(*w).Header().Set("Cache-Control", "max-age:300, public")
-	//(*w).Header().Set("Last-Modified", LastUpdate.Format(http.TimeFormat))
-	//(*w).Header().Set("Expires", time.Now().Add(time.Minute*5).Format(http.TimeFormat))
+	SetJsonHeader(w) // Cache-Control and the validators are set by the cache policy of the route
 }
 
 type GameVersion struct {
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// streamFlushEvery is the amount of entries written between two flushes. Writes block while the client is not
+// reading, so a slow client slows down rendering instead of piling up rendered entries.
+const streamFlushEvery = 64
+
+// StreamList writes count entries while they are rendered instead of building the whole response first. JSON and
+// MessagePack keep the layout of a single page under the given key, so clients see no difference to an unpaginated
+// listing. Rendering stops as soon as the request context is done.
+func StreamList(w http.ResponseWriter, r *http.Request, key string, count int, render func(i int) any) error {
+	format, ok := r.Context().Value("format").(string)
+	if !ok {
+		format = FormatJson
+	}
+
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", formatContentTypes[format])
+
+	controller := http.NewResponseController(w)
+	each := func(write func(i int, entry any) error) error {
+		for i := 0; i < count; i++ {
+			if err := r.Context().Err(); err != nil {
+				return err
+			}
+			if err := write(i, render(i)); err != nil {
+				return err
+			}
+			if (i+1)%streamFlushEvery == 0 {
+				_ = controller.Flush()
+			}
+		}
+		return controller.Flush()
+	}
+
+	switch format {
+	case FormatCsv:
+		return streamCsv(w, r, count, render, each)
+	case FormatNdjson:
+		encoder := json.NewEncoder(w)
+		return each(func(i int, entry any) error {
+			return encoder.Encode(entry)
+		})
+	case FormatMsgpack:
+		encoder := msgpack.NewEncoder(w)
+		encoder.SetCustomStructTag("json")
+		if err := encoder.EncodeMapLen(2); err != nil {
+			return err
+		}
+		if err := encoder.EncodeString("_links"); err != nil {
+			return err
+		}
+		if err := encoder.Encode(PaginationLinks{}); err != nil {
+			return err
+		}
+		if err := encoder.EncodeString(key); err != nil {
+			return err
+		}
+		if err := encoder.EncodeArrayLen(count); err != nil {
+			return err
+		}
+		return each(func(i int, entry any) error {
+			return encoder.Encode(entry)
+		})
+	default:
+		links, err := json.Marshal(PaginationLinks{})
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write([]byte(`{"_links":` + string(links) + `,"` + key + `":[`)); err != nil {
+			return err
+		}
+		err = each(func(i int, entry any) error {
+			encoded, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				encoded = append([]byte{','}, encoded...)
+			}
+			_, err = w.Write(encoded)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write([]byte("]}\n"))
+		return err
+	}
+}
+
+// streamCsv renders every entry twice. The first pass only collects the column names, because the header has to
+// be written before the first row.
+func streamCsv(w http.ResponseWriter, r *http.Request, count int, render func(i int) any, each func(func(i int, entry any) error) error) error {
+	var header []string
+	columnIndex := map[string]int{}
+	for i := 0; i < count; i++ {
+		if err := r.Context().Err(); err != nil {
+			return err
+		}
+		for _, column := range csvFlatten("", reflect.ValueOf(render(i))) {
+			if _, exists := columnIndex[column[0]]; !exists {
+				columnIndex[column[0]] = len(header)
+				header = append(header, column[0])
+			}
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	err := each(func(i int, entry any) error {
+		clear(record)
+		for _, column := range csvFlatten("", reflect.ValueOf(entry)) {
+			record[columnIndex[column[0]]] = column[1]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		if (i+1)%streamFlushEvery == 0 {
+			writer.Flush()
+		}
+		return writer.Error()
+	})
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,515 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/dofusdude/doduapi/config"
+	"github.com/dofusdude/doduapi/database"
+	e "github.com/dofusdude/doduapi/errmsg"
+	"github.com/dofusdude/doduapi/utils"
+	mapping "github.com/dofusdude/dodumap"
+	"github.com/hashicorp/go-memdb"
+	"github.com/meilisearch/meilisearch-go"
+)
+
+var (
+	weaponSortFields = utils.Concat(itemSortFields, []string{"critical_hit_probability", "critical_hit_bonus", "ap_cost"})
+
+	// Weapon item type IDs that take both hands, so no shield can be worn with them. The game data has no field for
+	// it, the list follows the in-game weapon descriptions. New weapon types are one-handed until they are added here.
+	twoHandedWeaponTypeIds = map[int]bool{
+		2:  true, // bow
+		4:  true, // staff
+		7:  true, // hammer
+		8:  true, // shovel
+		19: true, // axe
+		21: true, // pickaxe
+		22: true, // scythe
+	}
+)
+
+func isWeapon(item *mapping.MappedMultilangItemUnity) bool {
+	return item.Type.SuperTypeId == weaponSuperTypeId
+}
+
+// WeaponFilter restricts weapon listings by their weapon stats. The range filters bound the cast range, so
+// filter[min_range]=2 drops weapons that can hit at distance 1.
+type WeaponFilter struct {
+	ApCost    *int
+	MinRange  *int
+	MaxRange  *int
+	TwoHanded *bool
+}
+
+func parseWeaponFilter(query url.Values) (WeaponFilter, error) {
+	var filter WeaponFilter
+	for _, param := range []struct {
+		name  string
+		value **int
+	}{
+		{"filter[ap_cost]", &filter.ApCost},
+		{"filter[min_range]", &filter.MinRange},
+		{"filter[max_range]", &filter.MaxRange},
+	} {
+		if !query.Has(param.name) {
+			continue
+		}
+		value, err := strconv.Atoi(query.Get(param.name))
+		if err != nil || value < 0 {
+			return filter, fmt.Errorf("%s is not a positive number", param.name)
+		}
+		*param.value = &value
+	}
+
+	if query.Has("filter[two_handed]") {
+		twoHanded, err := strconv.ParseBool(strings.ToLower(query.Get("filter[two_handed]")))
+		if err != nil {
+			return filter, fmt.Errorf("filter[two_handed] is not a boolean")
+		}
+		filter.TwoHanded = &twoHanded
+	}
+
+	if filter.MinRange != nil && filter.MaxRange != nil && *filter.MinRange > *filter.MaxRange {
+		return filter, fmt.Errorf("filter[min_range] is greater than filter[max_range]")
+	}
+
+	return filter, nil
+}
+
+func (filter *WeaponFilter) matches(item *mapping.MappedMultilangItemUnity) bool {
+	if filter.ApCost != nil && item.ApCost != *filter.ApCost {
+		return false
+	}
+	if filter.MinRange != nil && item.MinRange < *filter.MinRange {
+		return false
+	}
+	if filter.MaxRange != nil && item.Range > *filter.MaxRange {
+		return false
+	}
+	if filter.TwoHanded != nil && twoHandedWeaponTypeIds[item.Type.ItemTypeId] != *filter.TwoHanded {
+		return false
+	}
+	return true
+}
+
+// meiliFilter returns the weapon stat filters for the search index, without the two-handed filter, which is a type
+// filter there.
+func (filter *WeaponFilter) meiliFilter() string {
+	var conditions []string
+	if filter.ApCost != nil {
+		conditions = append(conditions, fmt.Sprintf("weapon.ap_cost = %d", *filter.ApCost))
+	}
+	if filter.MinRange != nil {
+		conditions = append(conditions, fmt.Sprintf("weapon.min_range >= %d", *filter.MinRange))
+	}
+	if filter.MaxRange != nil {
+		conditions = append(conditions, fmt.Sprintf("weapon.range <= %d", *filter.MaxRange))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// weaponTypeSlugs returns the type filter names of all weapon types, optionally only the one or two-handed ones.
+func weaponTypeSlugs(txn *memdb.Txn, twoHanded *bool) ([]string, error) {
+	itemTypes, err := itemTypeEntries(txn)
+	if err != nil {
+		return nil, err
+	}
+
+	var slugs []string
+	for _, itemType := range itemTypes {
+		if itemType.SuperTypeId != weaponSuperTypeId {
+			continue
+		}
+		if twoHanded != nil && twoHandedWeaponTypeIds[itemType.AnkamaID] != *twoHanded {
+			continue
+		}
+		slugs = append(slugs, itemType.Slug)
+	}
+	return slugs, nil
+}
+
+type APIPageWeapon struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIWeapon           `json:"weapons"`
+}
+
+func (p APIPageWeapon) ListEntries() any {
+	return p.Items
+}
+
+// ListAllWeapons has no fields to add, weapons are always rendered in full.
+func ListAllWeapons(w http.ResponseWriter, r *http.Request) {
+	ListWeapons(w, r)
+}
+
+func ListWeapons(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	typeFiltering := strings.ToLower(r.URL.Query().Get("filter[type.name_id]"))
+	filterset := parseFields(typeFiltering)
+	additiveTypes, err := includeTypes(filterset, nil)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "filter[type.name_id] has invalid fields: "+err.Error())
+		return
+	}
+
+	removedTypes, err := excludeTypes(filterset, nil)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "filter[type.name_id] has invalid fields: "+err.Error())
+		return
+	}
+
+	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_level]"))
+	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_level]"))
+	filterMinLevelInt, filterMaxLevelInt, err := MinMaxLevelInt(filterMinLevel, filterMaxLevel, "level")
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, "filter[min_level] or filter[max_level] has invalid fields: "+err.Error())
+		return
+	}
+
+	weaponFilter, err := parseWeaponFilter(r.URL.Query())
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	effectFilters, err := parseEffectFilters(r.URL.Query())
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	sortKeys, err := parseSortParam(r.URL.Query().Get("sort"), "", weaponSortFields)
+	if err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
+	facetFields, ok := requestFacets(w, r)
+	if !ok {
+		return
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	if err = validateEffectFilters(effectFilters, txn); err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	if err = validateSortKeys(sortKeys, txn); err != nil {
+		e.WriteInvalidQueryResponse(w, "sort has invalid fields: "+err.Error())
+		return
+	}
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "equipment"), "id")
+	if err != nil || it == nil {
+		e.WriteNotFoundResponse(w, "No weapons found.")
+		return
+	}
+
+	utils.RequestsItemsList.Inc()
+	utils.RequestsTotal.Inc()
+
+	facets := newFacetCounts(facetFields)
+	var weapons []*mapping.MappedMultilangItemUnity
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*mapping.MappedMultilangItemUnity)
+		if !isWeapon(p) {
+			continue
+		}
+
+		enTypeName := itemTypeSlug(p.Type.Name["en"])
+		if removedTypes.Has(enTypeName) {
+			continue
+		}
+		if additiveTypes.Size() > 0 && !additiveTypes.Has(enTypeName) {
+			continue
+		}
+
+		if filterMinLevel != "" && p.Level < filterMinLevelInt {
+			continue
+		}
+		if filterMaxLevel != "" && p.Level > filterMaxLevelInt {
+			continue
+		}
+
+		if !weaponFilter.matches(p) {
+			continue
+		}
+
+		if !matchesEffectFilters(p.Effects, effectFilters) {
+			continue
+		}
+
+		weapons = append(weapons, p)
+		facets.addItem(p)
+	}
+
+	if len(weapons) == 0 {
+		e.WriteNotFoundResponse(w, "No weapons left after filtering.")
+		return
+	}
+
+	newEntitySorter(sortKeys, lang).SortItems(weapons)
+
+	if isStreamed(r) {
+		writeStream(w, r, "weapons", len(weapons), func(i int) any {
+			return utils.RenderLocalized(r, func(lang string) any {
+				return RenderWeapon(weapons[i], lang)
+			})
+		})
+		return
+	}
+
+	startIdx, endIdx, links, ok := pageBounds(w, r, weapons, sortKeys, func(weapon *mapping.MappedMultilangItemUnity) int {
+		return weapon.AnkamaId
+	})
+	if !ok {
+		return
+	}
+
+	included := newIncluder(includes, txn)
+	if err = included.addItems(weapons[startIdx:endIdx]...); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	if err = facets.loadNames(txn); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		paginatedWeapons := make([]APIWeapon, 0, endIdx-startIdx)
+		for _, p := range weapons[startIdx:endIdx] {
+			paginatedWeapons = append(paginatedWeapons, RenderWeapon(p, lang))
+		}
+
+		return APIPageWeapon{
+			Items: paginatedWeapons,
+			Links: links,
+		}
+	}), included, facets))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
+
+func GetSingleWeaponHandler(w http.ResponseWriter, r *http.Request) {
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "equipment"), "id", ankamaId)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	if raw == nil || !isWeapon(raw.(*mapping.MappedMultilangItemUnity)) {
+		if !redirectToItemCategory(w, r, ankamaId, txn) {
+			e.WriteNotFoundResponse(w, fmt.Sprintf("Could not find %s with ID %s in database", "weapon", strconv.Itoa(ankamaId)))
+		}
+		return
+	}
+
+	utils.RequestsTotal.Inc()
+	utils.RequestsItemsSingle.Inc()
+
+	weapon := raw.(*mapping.MappedMultilangItemUnity)
+	included := newIncluder(includes, txn)
+	if err = included.addItems(weapon); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		return RenderSingleItem(weapon, lang, txn)
+	}), included, nil))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}
+
+// SearchWeapons searches the items index restricted to weapon types. filter[two_handed] becomes a type filter, the
+// other weapon filters use the weapon stats of the index, so the limit applies to matching weapons only.
+func SearchWeapons(w http.ResponseWriter, r *http.Request) {
+	client := meilisearch.New(config.MeiliHost, meilisearch.WithAPIKey(config.MeiliKey))
+	defer client.Close()
+
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		e.WriteInvalidQueryResponse(w, "Query parameter is required.")
+		return
+	}
+
+	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_level]"))
+	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_level]"))
+	filterString, err := MinMaxLevelMeiliFilterFromParams(filterMinLevel, filterMaxLevel, "level")
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, "Min/Max level filter is invalid: "+err.Error())
+		return
+	}
+
+	lang := r.Context().Value("lang").(string)
+
+	var searchLimit int64
+	if searchLimit, err = getLimitInBoundary(r.URL.Query().Get("limit")); err != nil {
+		e.WriteInvalidQueryResponse(w, "Limit parameter is invalid: "+err.Error())
+		return
+	}
+
+	weaponFilter, err := parseWeaponFilter(r.URL.Query())
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, err.Error())
+		return
+	}
+
+	includes, ok := requestInclude(w, r, includeTypeItems)
+	if !ok {
+		return
+	}
+
+	facetFields, ok := requestFacets(w, r)
+	if !ok {
+		return
+	}
+	facets := newFacetCounts(facetFields)
+
+	typeFiltering := strings.ToLower(r.URL.Query().Get("filter[type.name_id]"))
+	filterset := parseFields(typeFiltering)
+	additiveTypes, err := includeTypes(filterset, nil)
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, "filter[type.name_id] is invalid: "+err.Error())
+		return
+	}
+
+	removedTypes, err := excludeTypes(filterset, nil)
+	if err != nil {
+		e.WriteInvalidFilterResponse(w, "filter[type.name_id] is invalid: "+err.Error())
+		return
+	}
+
+	txn := database.Db.Txn(false)
+	defer txn.Abort()
+
+	weaponTypes, err := weaponTypeSlugs(txn, weaponFilter.TwoHanded)
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	var searchTypes []string
+	for _, weaponType := range weaponTypes {
+		if removedTypes.Has(weaponType) || additiveTypes.Size() > 0 && !additiveTypes.Has(weaponType) {
+			continue
+		}
+		searchTypes = append(searchTypes, weaponType)
+	}
+
+	if len(searchTypes) == 0 {
+		e.WriteNotFoundResponse(w, "No weapon types left after filtering.")
+		return
+	}
+
+	if filterString != "" {
+		filterString += " AND "
+	}
+	filterString += "super_type.name_id=equipment AND (type.name_id=" + strings.Join(searchTypes, " OR type.name_id=") + ")"
+	if statFilter := weaponFilter.meiliFilter(); statFilter != "" {
+		filterString += " AND " + statFilter
+	}
+
+	index := client.Index(fmt.Sprintf("%s-all_items-%s", utils.CurrentRedBlueVersionStr(database.Version.Search), lang))
+	searchResp, err := index.Search(query, &meilisearch.SearchRequest{
+		Limit:  searchLimit,
+		Filter: filterString,
+		Facets: facets.searchFacets(),
+	})
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not search: "+err.Error())
+		return
+	}
+
+	utils.RequestsTotal.Inc()
+	utils.RequestsItemsSearch.Inc()
+
+	if searchResp.EstimatedTotalHits == 0 {
+		e.WriteNotFoundResponse(w, "No results found.")
+		return
+	}
+
+	if err = facets.addSearchResponse(searchResp); err != nil {
+		e.WriteServerErrorResponse(w, "Could not decode facets: "+err.Error())
+		return
+	}
+
+	var found []*mapping.MappedMultilangItemUnity
+	for _, hitRaw := range searchResp.Hits {
+		indexed := Hit{}
+		if err = hitRaw.DecodeInto(&indexed); err != nil {
+			e.WriteServerErrorResponse(w, "Could not decode hit: "+err.Error())
+			return
+		}
+
+		itemId := int(indexed.Id)
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(database.Version.MemDb), "equipment"), "id", itemId)
+		if err != nil {
+			e.WriteServerErrorResponse(w, "Could not find item in database: "+err.Error())
+			return
+		}
+
+		if raw == nil {
+			log.Warn("Item not found in memdb.", "id", itemId)
+			continue
+		}
+
+		found = append(found, raw.(*mapping.MappedMultilangItemUnity))
+	}
+
+	included := newIncluder(includes, txn)
+	if err = included.addItems(found...); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	if err = facets.loadNames(txn); err != nil {
+		e.WriteServerErrorResponse(w, "Could not read database: "+err.Error())
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = utils.WriteData(w, r, wrapResponse(r, utils.RenderLocalized(r, func(lang string) any {
+		weapons := make([]APIWeapon, len(found))
+		for i, weapon := range found {
+			weapons[i] = RenderWeapon(weapon, lang)
+		}
+		return weapons
+	}), included, facets))
+	if err != nil {
+		e.WriteServerErrorResponse(w, "Could not encode response: "+err.Error())
+		return
+	}
+}